@@ -0,0 +1,413 @@
+package table
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// binaryMagic are the first bytes of every binary encoded table.
+const binaryMagic = "TBL"
+
+// binaryVersion is the version of the binary format.
+const binaryVersion byte = 1
+
+// binaryHeaderSize is the size, in bytes, of the header of the binary format:
+// the magic, the version, the type tag, the byte order, the width and the height.
+const binaryHeaderSize = len(binaryMagic) + 3 + 2*4
+
+const (
+	// littleEndian is the byte order tag of little-endian data. It is the only
+	// byte order written.
+	littleEndian byte = iota
+
+	// bigEndian is the byte order tag of big-endian data.
+	bigEndian
+)
+
+// BinaryCell is the set of cell types that have a binary encoding.
+type BinaryCell interface {
+	bool | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64 |
+		float32 | float64 | complex64 | complex128 | int | uint | uintptr
+}
+
+// binaryTag returns the type tag and the size, in bytes, of the cells of type T.
+// Booleans are bit-packed and have a size of 0; int, uint and uintptr are always
+// encoded on 8 bytes.
+//
+// Returns:
+//   - byte: The type tag.
+//   - int: The size of a cell.
+func binaryTag[T BinaryCell]() (byte, int) {
+	switch any(*new(T)).(type) {
+	case bool:
+		return 1, 0
+	case int8:
+		return 2, 1
+	case int16:
+		return 3, 2
+	case int32:
+		return 4, 4
+	case int64:
+		return 5, 8
+	case uint8:
+		return 6, 1
+	case uint16:
+		return 7, 2
+	case uint32:
+		return 8, 4
+	case uint64:
+		return 9, 8
+	case float32:
+		return 10, 4
+	case float64:
+		return 11, 8
+	case complex64:
+		return 12, 8
+	case complex128:
+		return 13, 16
+	case int:
+		return 14, 8
+	case uint:
+		return 15, 8
+	default: // uintptr
+		return 16, 8
+	}
+}
+
+// MarshalBinaryCells encodes the given cells as a little-endian header followed by
+// the packed cells, row by row. It is used by the MarshalBinary method of the tables.
+//
+// Tables without columns have no cells; their rows are encoded as one zero byte
+// each instead, so that the size of the encoding grows with the number of rows.
+//
+// Parameters:
+//   - cells: The rows of cells.
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - []byte: The encoded table.
+//   - error: Always nil.
+func MarshalBinaryCells[T BinaryCell](cells [][]T, width, height int) ([]byte, error) {
+	tag, size := binaryTag[T]()
+
+	var data_size int
+
+	if width == 0 {
+		data_size = height
+	} else if size == 0 {
+		data_size = (width*height + 7) / 8
+	} else {
+		data_size = width * height * size
+	}
+
+	data := make([]byte, 0, binaryHeaderSize+data_size)
+	data = append(data, binaryMagic...)
+	data = append(data, binaryVersion, tag, littleEndian)
+	data = binary.LittleEndian.AppendUint32(data, uint32(width))
+	data = binary.LittleEndian.AppendUint32(data, uint32(height))
+
+	if width == 0 {
+		return append(data, make([]byte, height)...), nil
+	}
+
+	le := binary.LittleEndian
+
+	switch rows := any(cells).(type) {
+	case [][]bool:
+		packed := make([]byte, data_size)
+
+		for y := 0; y < height; y++ {
+			for x, cell := range rows[y][:width] {
+				if cell {
+					idx := y*width + x
+					packed[idx/8] |= 1 << (idx % 8)
+				}
+			}
+		}
+
+		data = append(data, packed...)
+	case [][]int8:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = append(data, byte(cell))
+			}
+		}
+	case [][]int16:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint16(data, uint16(cell))
+			}
+		}
+	case [][]int32:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint32(data, uint32(cell))
+			}
+		}
+	case [][]int64:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, uint64(cell))
+			}
+		}
+	case [][]uint8:
+		for _, row := range rows[:height] {
+			data = append(data, row[:width]...)
+		}
+	case [][]uint16:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint16(data, cell)
+			}
+		}
+	case [][]uint32:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint32(data, cell)
+			}
+		}
+	case [][]uint64:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, cell)
+			}
+		}
+	case [][]float32:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint32(data, math.Float32bits(cell))
+			}
+		}
+	case [][]float64:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, math.Float64bits(cell))
+			}
+		}
+	case [][]complex64:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint32(data, math.Float32bits(real(cell)))
+				data = le.AppendUint32(data, math.Float32bits(imag(cell)))
+			}
+		}
+	case [][]complex128:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, math.Float64bits(real(cell)))
+				data = le.AppendUint64(data, math.Float64bits(imag(cell)))
+			}
+		}
+	case [][]int:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, uint64(cell))
+			}
+		}
+	case [][]uint:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, uint64(cell))
+			}
+		}
+	case [][]uintptr:
+		for _, row := range rows[:height] {
+			for _, cell := range row[:width] {
+				data = le.AppendUint64(data, uint64(cell))
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinaryCells decodes a table encoded by MarshalBinaryCells. Big-endian
+// data is accepted as well. It is used by the UnmarshalBinary method of the tables.
+//
+// The size declared by the header is checked against the size of the data before
+// anything is allocated; hence, the memory used, rows included, is proportional to
+// len(data).
+//
+// Parameters:
+//   - data: The encoded table.
+//
+// Returns:
+//   - [][]T: The rows of cells.
+//   - int: The width of the table.
+//   - int: The height of the table.
+//   - error: An error if the data is not a valid table of cells of type T.
+func UnmarshalBinaryCells[T BinaryCell](data []byte) ([][]T, int, int, error) {
+	tag, size := binaryTag[T]()
+
+	if len(data) < binaryHeaderSize || string(data[:len(binaryMagic)]) != binaryMagic {
+		return nil, 0, 0, fmt.Errorf("data is not a binary encoded table")
+	}
+
+	header := data[len(binaryMagic):]
+
+	if header[0] != binaryVersion {
+		return nil, 0, 0, fmt.Errorf("unsupported version %d", header[0])
+	} else if header[1] != tag {
+		return nil, 0, 0, fmt.Errorf("expected type tag %d, got %d", tag, header[1])
+	}
+
+	var order binary.ByteOrder
+
+	switch header[2] {
+	case littleEndian:
+		order = binary.LittleEndian
+	case bigEndian:
+		order = binary.BigEndian
+	default:
+		return nil, 0, 0, fmt.Errorf("unknown byte order %d", header[2])
+	}
+
+	w, h := uint64(order.Uint32(header[3:])), uint64(order.Uint32(header[7:]))
+
+	data = data[binaryHeaderSize:]
+
+	if w == 0 {
+		// The rows of tables without columns are encoded as zero bytes.
+		if h != uint64(len(data)) || slices.ContainsFunc(data, func(b byte) bool { return b != 0 }) {
+			return nil, 0, 0, fmt.Errorf("table of 0x%d cells does not match %d bytes of rows", h, len(data))
+		}
+
+		cells := make([][]T, 0, h)
+
+		for range h {
+			cells = append(cells, []T{})
+		}
+
+		return cells, 0, int(h), nil
+	}
+
+	// count fits in 64 bits since both w and h fit in 32 bits.
+	_, count := bits.Mul64(w, h)
+
+	var data_size uint64
+	var overflow uint64
+
+	if size == 0 {
+		data_size = count/8 + min(count%8, 1)
+	} else {
+		overflow, data_size = bits.Mul64(count, uint64(size))
+	}
+
+	if overflow != 0 || data_size != uint64(len(data)) {
+		return nil, 0, 0, fmt.Errorf("table of %dx%d cells does not match %d bytes of cells", w, h, len(data))
+	}
+
+	width, height := int(w), int(h)
+
+	// The rows share one backing slice whose length is bounded by len(data).
+	buf := make([]T, width*height)
+	cells := make([][]T, 0, height)
+
+	for y := 0; y < height; y++ {
+		row := buf[y*width : (y+1)*width : (y+1)*width]
+		decodeRow(order, row, data, y*width, size)
+
+		cells = append(cells, row)
+	}
+
+	if tag >= 14 && bits.UintSize == 32 {
+		// int, uint and uintptr were encoded on 8 bytes; check that they fit.
+		for i := 0; i < int(count); i++ {
+			v := order.Uint64(data[i*8:])
+
+			if (tag == 14 && int64(v) != int64(int32(v))) || (tag != 14 && v > math.MaxUint32) {
+				return nil, 0, 0, NewErrCell(i%width, i/width, fmt.Errorf("value %d overflows a %d-bit integer", v, bits.UintSize))
+			}
+		}
+	}
+
+	return cells, width, height, nil
+}
+
+// decodeRow decodes a row of cells.
+//
+// Parameters:
+//   - order: The byte order of the data.
+//   - row: The row to fill.
+//   - data: The encoded cells of the whole table.
+//   - start: The index, among all the cells of the table, of the first cell of the row.
+//   - size: The size, in bytes, of a cell. 0 for bit-packed booleans.
+func decodeRow[T BinaryCell](order binary.ByteOrder, row []T, data []byte, start, size int) {
+	if size != 0 {
+		data = data[start*size:]
+	}
+
+	switch row := any(row).(type) {
+	case []bool:
+		for x := range row {
+			idx := start + x
+			row[x] = data[idx/8]&(1<<(idx%8)) != 0
+		}
+	case []int8:
+		for x := range row {
+			row[x] = int8(data[x])
+		}
+	case []int16:
+		for x := range row {
+			row[x] = int16(order.Uint16(data[x*2:]))
+		}
+	case []int32:
+		for x := range row {
+			row[x] = int32(order.Uint32(data[x*4:]))
+		}
+	case []int64:
+		for x := range row {
+			row[x] = int64(order.Uint64(data[x*8:]))
+		}
+	case []uint8:
+		copy(row, data)
+	case []uint16:
+		for x := range row {
+			row[x] = order.Uint16(data[x*2:])
+		}
+	case []uint32:
+		for x := range row {
+			row[x] = order.Uint32(data[x*4:])
+		}
+	case []uint64:
+		for x := range row {
+			row[x] = order.Uint64(data[x*8:])
+		}
+	case []float32:
+		for x := range row {
+			row[x] = math.Float32frombits(order.Uint32(data[x*4:]))
+		}
+	case []float64:
+		for x := range row {
+			row[x] = math.Float64frombits(order.Uint64(data[x*8:]))
+		}
+	case []complex64:
+		for x := range row {
+			re := math.Float32frombits(order.Uint32(data[x*8:]))
+			im := math.Float32frombits(order.Uint32(data[x*8+4:]))
+			row[x] = complex(re, im)
+		}
+	case []complex128:
+		for x := range row {
+			re := math.Float64frombits(order.Uint64(data[x*16:]))
+			im := math.Float64frombits(order.Uint64(data[x*16+8:]))
+			row[x] = complex(re, im)
+		}
+	case []int:
+		for x := range row {
+			row[x] = int(order.Uint64(data[x*8:]))
+		}
+	case []uint:
+		for x := range row {
+			row[x] = uint(order.Uint64(data[x*8:]))
+		}
+	case []uintptr:
+		for x := range row {
+			row[x] = uintptr(order.Uint64(data[x*8:]))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package table_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	testRoundTrip(t, binaryCodec, []roundTripCase{
+		{name: "BoolTable", src: must(table.NewBoolTableFromRows([][]bool{{true, false, true}, {false, true, true}, {true, true, false}}))},
+		{name: "ByteTable", src: must(table.NewByteTableFromRows([][]byte{{0, 255}, {1, 2}}))},
+		{name: "Complex64Table", src: must(table.NewComplex64TableFromRows([][]complex64{{1 + 2i, 0}, {-3.5i, 4}}))},
+		{name: "Complex128Table", src: must(table.NewComplex128TableFromRows([][]complex128{{1 + 2i, 0}, {-3.5i, 4}}))},
+		{name: "Float32Table", src: must(table.NewFloat32TableFromRows([][]float32{{0.1, -2}, {3e30, 0}}))},
+		{name: "Float64Table", src: must(table.NewFloat64TableFromRows([][]float64{{0.1, -2}, {3e300, 0}}))},
+		{name: "IntTable", src: must(table.NewIntTableFromRows([][]int{{-1, 2}, {3, 1 << 30}}))},
+		{name: "Int8Table", src: must(table.NewInt8TableFromRows([][]int8{{-128, 127}}))},
+		{name: "Int16Table", src: must(table.NewInt16TableFromRows([][]int16{{-32768, 32767}}))},
+		{name: "Int32Table", src: must(table.NewInt32TableFromRows([][]int32{{-1 << 31, 1<<31 - 1}}))},
+		{name: "Int64Table", src: must(table.NewInt64TableFromRows([][]int64{{-1 << 63, 1<<63 - 1}}))},
+		{name: "RuneTable", src: must(table.NewRuneTableFromRows([][]rune{{'a', 0}, {'é', '世'}}))},
+		{name: "UintTable", src: must(table.NewUintTableFromRows([][]uint{{0, 1 << 31}}))},
+		{name: "Uint8Table", src: must(table.NewUint8TableFromRows([][]uint8{{0, 255}}))},
+		{name: "Uint16Table", src: must(table.NewUint16TableFromRows([][]uint16{{0, 65535}}))},
+		{name: "Uint32Table", src: must(table.NewUint32TableFromRows([][]uint32{{0, 1<<32 - 1}}))},
+		{name: "Uint64Table", src: must(table.NewUint64TableFromRows([][]uint64{{0, 1<<64 - 1}}))},
+		{name: "UintptrTable", src: must(table.NewUintptrTableFromRows([][]uintptr{{0, 1 << 20}}))},
+		{name: "empty", src: must(table.NewIntTable(0, 0))},
+		{name: "no columns", src: must(table.NewIntTable(0, 3))},
+		{name: "no columns BoolTable", src: must(table.NewBoolTable(0, 2))},
+	})
+}
+
+func TestUnmarshalBinaryRejectsBadSizes(t *testing.T) {
+	valid := must(must(table.NewIntTableFromRows([][]int{{1}})).MarshalBinary())
+
+	// withSize returns the header of valid with the given size and the given
+	// number of bytes of cells.
+	withSize := func(width, height uint32, cells int) []byte {
+		data := append([]byte(nil), valid[:14]...)
+		binary.LittleEndian.PutUint32(data[6:], width)
+		binary.LittleEndian.PutUint32(data[10:], height)
+
+		return append(data, make([]byte, cells)...)
+	}
+
+	tests := map[string][]byte{
+		"no columns":      withSize(0, 0x7fffffff, 0),
+		"missing rows":    withSize(0, 3, 2),
+		"nonzero rows":    append(withSize(0, 1, 0), 1),
+		"huge table":      withSize(0xffffffff, 0xffffffff, 8),
+		"single long row": withSize(0x7fffffff, 1, 8),
+		"truncated":       withSize(2, 2, 24),
+		"trailing data":   withSize(1, 1, 9),
+		"short header":    valid[:10],
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var tbl table.IntTable
+
+			err := tbl.UnmarshalBinary(data)
+			if err == nil {
+				t.Errorf("got a %dx%d table, want an error", tbl.Width(), tbl.Height())
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package table_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestFixBoundaries(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, y         int
+		want         [][]int
+		wantX, wantY int
+	}{
+		{"inside", 1, 1, [][]int{{1, 2}, {3, 4}}, 1, 1},
+		{"clipped right and bottom", 2, 2, [][]int{{1}}, 2, 2},
+		{"clipped left and top", -1, -1, [][]int{{4}}, 0, 0},
+		{"far above", 0, -5, [][]int{}, 0, 0},
+		{"far left", -5, 0, [][]int{nil, nil}, 0, 0},
+		{"far below", 0, 9, [][]int{}, 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elems := [][]int{{1, 2}, {3, 4}}
+			x, y := tt.x, tt.y
+
+			got := table.FixBoundaries(3, 3, elems, &x, &y)
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			if x != tt.wantX || y != tt.wantY {
+				t.Errorf("got (%d, %d), want (%d, %d)", x, y, tt.wantX, tt.wantY)
+			}
+
+			if !reflect.DeepEqual(elems, [][]int{{1, 2}, {3, 4}}) {
+				t.Errorf("elements were modified: %v", elems)
+			}
+		})
+	}
+}
+
+func TestSequenceWritersClipNegativeStart(t *testing.T) {
+	tbl := must(table.NewIntTable(3, 2))
+
+	x, y := 0, -1
+	tbl.WriteVerticalSequence(&x, &y, []int{1, 2, 3})
+
+	if y != 2 {
+		t.Errorf("y is %d, want 2", y)
+	}
+
+	x, y = -2, 0
+	tbl.WriteHorizontalSequence(&x, &y, []int{4, 5, 6, 7})
+
+	if x != 2 {
+		t.Errorf("x is %d, want 2", x)
+	}
+
+	x, y = 1, -5
+	tbl.WriteVerticalSequence(&x, &y, []int{8, 9})
+
+	want := [][]int{{6, 7, 0}, {3, 0, 0}}
+	if got := tbl.FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteTableAtNegativeOffset(t *testing.T) {
+	dst := must(table.NewIntTable(3, 3))
+	src := must(table.NewIntTableFromRows([][]int{{1, 2}, {3, 4}}))
+
+	x, y := -1, -1
+	dst.WriteTableAt(src, &x, &y)
+
+	want := [][]int{{4, 0, 0}, {0, 0, 0}, {0, 0, 0}}
+	if got := dst.FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResizeHeightAddsFullRows(t *testing.T) {
+	tbl := must(table.NewIntTable(2, 1))
+
+	err := tbl.ResizeHeight(3)
+	if err != nil {
+		t.Fatalf("ResizeHeight: %v", err)
+	}
+
+	tbl.WriteAt(1, 2, 5)
+
+	want := [][]int{{0, 0}, {0, 0}, {0, 5}}
+	if got := tbl.FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,152 @@
+package table
+
+import (
+	"strconv"
+
+	"github.com/PlayerR9/go-commons/ints"
+)
+
+// BoundsPolicy tells a table what to do with coordinates that fall outside of it.
+type BoundsPolicy int
+
+const (
+	// BoundsClip ignores out-of-bounds cells: reads return the zero value and writes
+	// do nothing. This is the default policy and the error-returning variants of the
+	// methods do not report out-of-bounds cells.
+	BoundsClip BoundsPolicy = iota
+
+	// BoundsWrap wraps out-of-bounds coordinates around the table, as if it were a
+	// torus. For instance, the column -1 is the last column of the table.
+	BoundsWrap
+
+	// BoundsClamp moves out-of-bounds coordinates to the closest cell of the table.
+	BoundsClamp
+
+	// BoundsError rejects out-of-bounds cells. The error-returning variants of the
+	// methods report them with an *ints.ErrOutOfBounds error, while the others ignore
+	// them as with BoundsClip. Moreover, sequences are written either entirely or
+	// not at all.
+	BoundsError
+
+	// BoundsGrow resizes the table when a write falls past its right or bottom edge.
+	// Reads and writes at negative coordinates are handled as with BoundsError.
+	BoundsGrow
+)
+
+// String implements the fmt.Stringer interface.
+func (p BoundsPolicy) String() string {
+	switch p {
+	case BoundsClip:
+		return "clip"
+	case BoundsWrap:
+		return "wrap"
+	case BoundsClamp:
+		return "clamp"
+	case BoundsError:
+		return "error"
+	case BoundsGrow:
+		return "grow"
+	default:
+		return "BoundsPolicy(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// IsValid checks whether the policy is one of the predefined policies.
+//
+// Returns:
+//   - bool: True if the policy is valid, false otherwise.
+func (p BoundsPolicy) IsValid() bool {
+	return p >= BoundsClip && p <= BoundsGrow
+}
+
+// Resolve maps the given coordinates to a cell of a table of the given size.
+//
+// Parameters:
+//   - x: The x-coordinate to map.
+//   - y: The y-coordinate to map.
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - int: The x-coordinate of the cell.
+//   - int: The y-coordinate of the cell.
+//   - error: An error if the coordinates do not map to any cell.
+//
+// Errors:
+//   - *ints.ErrOutOfBounds: If the coordinates are out of bounds and the policy is
+//     neither BoundsWrap nor BoundsClamp, or if the table is empty.
+//
+// Resolve does not grow tables; with BoundsGrow, callers are expected to grow the
+// table before resolving the coordinates of a write.
+func (p BoundsPolicy) Resolve(x, y, width, height int) (int, int, error) {
+	if x >= 0 && x < width && y >= 0 && y < height {
+		return x, y, nil
+	}
+
+	if width <= 0 || height <= 0 {
+		return 0, 0, outOfBounds(x, y, width, height)
+	}
+
+	switch p {
+	case BoundsWrap:
+		x %= width
+		if x < 0 {
+			x += width
+		}
+
+		y %= height
+		if y < 0 {
+			y += height
+		}
+	case BoundsClamp:
+		x = min(max(x, 0), width-1)
+		y = min(max(y, 0), height-1)
+	default:
+		return 0, 0, outOfBounds(x, y, width, height)
+	}
+
+	return x, y, nil
+}
+
+// outOfBounds creates the error reporting the first out-of-bounds coordinate of
+// the given cell.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - *ints.ErrOutOfBounds: The error. Never returns nil.
+func outOfBounds(x, y, width, height int) *ints.ErrOutOfBounds {
+	if x < 0 || x >= width {
+		return ints.NewErrOutOfBounds(x, 0, width)
+	}
+
+	return ints.NewErrOutOfBounds(y, 0, height)
+}
+
+// CheckSpan checks whether the given number of cells starting at the given position
+// fit in [0, size); that is, whether a sequence can be written entirely.
+//
+// Parameters:
+//   - start: The position of the first cell.
+//   - n: The number of cells.
+//   - size: The number of available positions.
+//
+// Returns:
+//   - error: An error if the cells do not fit.
+//
+// Errors:
+//   - *ints.ErrOutOfBounds: If a cell is out of bounds. The first out-of-bounds
+//     position is reported.
+func CheckSpan(start, n, size int) error {
+	if start < 0 || start >= size {
+		return ints.NewErrOutOfBounds(start, 0, size)
+	} else if n > size-start {
+		return ints.NewErrOutOfBounds(size, 0, size)
+	}
+
+	return nil
+}
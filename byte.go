@@ -2,18 +2,30 @@
 package table
 
 import (
-	"iter"	
+	"encoding/json"
+	"fmt"
+	"image"
+	"io"
+	"iter"
+	"strconv"
 
 	"github.com/PlayerR9/go-commons/errors"
 	"github.com/PlayerR9/go-commons/ints"
+	"github.com/PlayerR9/table/geom"
 )
 
 // ByteTable represents a table of cells that can be drawn to the screen.
+//
+// Every method that modifies the table has a pointer receiver, since writes may grow
+// the table; see BoundsGrow.
 type ByteTable struct {
 	table         [][]byte
 	width, height int
+	policy        BoundsPolicy
 }
 
+var _ ReadWriter[byte] = (*ByteTable)(nil)
+
 // NewByteTable creates a new table of type byte with the given width and height.
 // Negative parameters are treated as absolute values.
 //
@@ -93,8 +105,13 @@ func (t ByteTable) Row() iter.Seq[[]byte] {
 
 // Cleanup is a method that cleans up the table.
 //
-// It sets all cells in the table to the zero value of type byte.
-func (t ByteTable) Cleanup() {
+// It sets all cells in the table to the zero value of type byte. If the
+// receiver is nil, nothing happens.
+func (t *ByteTable) Cleanup() {
+	if t == nil {
+		return
+	}
+
 	for i := 0; i < t.height; i++ {
 		for j := 0; j < t.width; j++ {
 			t.table[i][j] = 0
@@ -118,23 +135,105 @@ func (t ByteTable) Height() int {
 	return t.height
 }
 
-// WriteAt writes a cell to the table at the given coordinates. However, out-of-bounds
-// coordinates do nothing.
+// BoundsPolicy returns the policy the table uses for out-of-bounds coordinates.
+//
+// Returns:
+//   - BoundsPolicy: The bounds policy. Defaults to BoundsClip.
+func (t ByteTable) BoundsPolicy() BoundsPolicy {
+	return t.policy
+}
+
+// SetBoundsPolicy sets the policy the table uses for out-of-bounds coordinates. It
+// is honoured by CellAt, WriteAt, the sequence writers and their variants.
+//
+// Parameters:
+//   - policy: The new bounds policy.
+//
+// Returns:
+//   - error: An error if the policy could not be set.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the policy is not valid.
+//   - errors.NilReceiver: If the table is nil.
+func (t *ByteTable) SetBoundsPolicy(policy BoundsPolicy) error {
+	if t == nil {
+		return errors.NilReceiver
+	} else if !policy.IsValid() {
+		return errors.NewErrInvalidParameter("policy", fmt.Errorf("unknown bounds policy %v", policy))
+	}
+
+	t.policy = policy
+
+	return nil
+}
+
+// grow grows the table so that it has at least the given number of columns and rows.
+//
+// Parameters:
+//   - width: The minimum width of the table.
+//   - height: The minimum height of the table.
+func (t *ByteTable) grow(width, height int) {
+	if width > t.width {
+		_ = t.ResizeWidth(width)
+	}
+
+	if height > t.height {
+		_ = t.ResizeHeight(height)
+	}
+}
+
+// WriteAt writes a cell to the table at the given coordinates. Out-of-bounds
+// coordinates are handled according to the bounds policy of the table; by default,
+// they do nothing.
 //
 // Parameters:
 //   - x: The x-coordinate of the cell.
 //   - y: The y-coordinate of the cell.
 //   - cell: The cell to write to the table.
-func (t ByteTable) WriteAt(x, y int, cell byte) {
-	if x < 0 || x >= t.width || y < 0 || y >= t.height {
-		return
+//
+// See TryWriteAt for a variant that reports out-of-bounds coordinates.
+func (t *ByteTable) WriteAt(x, y int, cell byte) {
+	_ = t.TryWriteAt(x, y, cell)
+}
+
+// TryWriteAt is the equivalent of WriteAt but reports the coordinates the bounds
+// policy rejects.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write to the table.
+//
+// Returns:
+//   - error: An error if the cell could not be written.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - *ints.ErrOutOfBounds: If the coordinates are out of bounds and the policy is
+//     either BoundsError or BoundsGrow, or if the table is empty and the
+//     policy is either BoundsWrap or BoundsClamp.
+func (t *ByteTable) TryWriteAt(x, y int, cell byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	}
+
+	if t.policy == BoundsGrow && x >= 0 && y >= 0 {
+		t.grow(x+1, y+1)
 	}
 
-	t.table[y][x] = cell
+	actualX, actualY, err := t.policy.Resolve(x, y, t.width, t.height)
+	if err == nil {
+		t.table[actualY][actualX] = cell
+	} else if t.policy == BoundsClip {
+		err = nil
+	}
+
+	return err
 }
 
-// CellAt returns the cell at the given coordinates in the table. However, out-of-bounds
-// coordinates return 0.
+// CellAt returns the cell at the given coordinates in the table. Out-of-bounds
+// coordinates are handled according to the bounds policy of the table; by default,
+// they return 0.
 //
 // Parameters:
 //   - x: The x-coordinate of the cell.
@@ -142,14 +241,43 @@ func (t ByteTable) WriteAt(x, y int, cell byte) {
 //
 // Returns:
 //   - byte: The cell at the given coordinates.
+//
+// See TryCellAt for a variant that reports out-of-bounds coordinates.
 func (t ByteTable) CellAt(x, y int) byte {
-	if x < 0 || x >= t.width || y < 0 || y >= t.height {
+	x, y, err := t.policy.Resolve(x, y, t.width, t.height)
+	if err != nil {
 		return 0
 	} else {
 		return t.table[y][x]
 	}
 }
 
+// TryCellAt is the equivalent of CellAt but reports the coordinates the bounds
+// policy rejects.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - byte: The cell at the given coordinates. 0 if the cell could not be read.
+//   - error: An error if the cell could not be read.
+//
+// Errors:
+//   - *ints.ErrOutOfBounds: If the coordinates are out of bounds and the policy is
+//     either BoundsError or BoundsGrow, or if the table is empty and the
+//     policy is either BoundsWrap or BoundsClamp.
+func (t ByteTable) TryCellAt(x, y int) (byte, error) {
+	actualX, actualY, err := t.policy.Resolve(x, y, t.width, t.height)
+	if err == nil {
+		return t.table[actualY][actualX], nil
+	} else if t.policy == BoundsClip {
+		return 0, nil
+	} else {
+		return 0, err
+	}
+}
+
 // WriteVerticalSequence is a function that writes the specified values to the table
 // starting from the specified coordinates (top = 0, 0) and continuing down the
 // table in the vertical direction until either the sequence is exhausted or
@@ -184,22 +312,88 @@ func (t ByteTable) CellAt(x, y int) byte {
 //
 // As you can see, the 'g' value was ignored as it would be out-of-bounds.
 // Finally, if either x or y is nil, the function does nothing.
-func (t ByteTable) WriteVerticalSequence(x, y *int, sequence []byte) {
-	if x == nil || y == nil || len(sequence) == 0 {
-		return
+//
+// The above describes the default bounds policy. With BoundsWrap and
+// BoundsClamp, every value is written at the cell its coordinates map to
+// and y is moved past the end of the sequence; with BoundsError, nothing
+// is written unless the whole sequence fits; and, with BoundsGrow, the table
+// is grown to fit the sequence unless x or y is negative, in which case nothing is
+// written. See TryWriteVerticalSequence for a variant that reports errors.
+func (t *ByteTable) WriteVerticalSequence(x, y *int, sequence []byte) {
+	_ = t.TryWriteVerticalSequence(x, y, sequence)
+}
+
+// TryWriteVerticalSequence is the equivalent of WriteVerticalSequence but reports
+// the sequences the bounds policy rejects.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell. (Never changes)
+//   - y: The y-coordinate of the starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - error: An error if the sequence could not be written.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - *errors.ErrInvalidParameter: If x or y is nil.
+//   - *ints.ErrOutOfBounds: If the sequence does not fit in the table and the policy
+//     is either BoundsError or BoundsGrow, or if the table is empty and
+//     the policy is either BoundsWrap or BoundsClamp.
+func (t *ByteTable) TryWriteVerticalSequence(x, y *int, sequence []byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	} else if x == nil {
+		return errors.NewErrNilParameter("x")
+	} else if y == nil {
+		return errors.NewErrNilParameter("y")
+	} else if len(sequence) == 0 {
+		return nil
 	}
 
 	actualX, actualY := *x, *y
 
-	if actualX < 0 || actualX >= t.width || actualY >= t.height {
-		return
+	switch t.policy {
+	case BoundsWrap, BoundsClamp:
+		for i, cell := range sequence {
+			cellX, cellY, err := t.policy.Resolve(actualX, actualY+i, t.width, t.height)
+			if err != nil {
+				return err
+			}
+
+			t.table[cellY][cellX] = cell
+		}
+
+		*y = actualY + len(sequence)
+
+		return nil
+	case BoundsGrow:
+		if actualX >= 0 && actualY >= 0 {
+			t.grow(actualX+1, actualY+len(sequence))
+		}
+
+		fallthrough
+	case BoundsError:
+		err := CheckSpan(actualX, 1, t.width)
+		if err == nil {
+			err = CheckSpan(actualY, len(sequence), t.height)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if actualX < 0 || actualX >= t.width || actualY >= t.height || actualY+len(sequence) <= 0 {
+		return nil
 	}
 
 	if actualY < 0 {
 		sequence = sequence[-actualY:]
+		actualY = 0
+	}
 
-		*y = 0
-	} else if actualY+len(sequence) > t.height {
+	if actualY+len(sequence) > t.height {
 		sequence = sequence[:t.height-actualY]
 	}
 
@@ -207,7 +401,9 @@ func (t ByteTable) WriteVerticalSequence(x, y *int, sequence []byte) {
 		t.table[actualY+i][actualX] = cell
 	}
 
-	*y += len(sequence)
+	*y = actualY + len(sequence)
+
+	return nil
 }
 
 // WriteHorizontalSequence is the equivalent of WriteVerticalSequence but for horizontal
@@ -217,30 +413,86 @@ func (t ByteTable) WriteVerticalSequence(x, y *int, sequence []byte) {
 //
 // Parameters:
 //   - x: The x-coordinate of the starting cell.
-//   - y: The y-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell. (Never changes)
 //   - sequence: The sequence of cells to write to the table.
-func (t ByteTable) WriteHorizontalSequence(x, y *int, sequence []byte) {
-	if x == nil || y == nil || len(sequence) == 0 {
-		return
+func (t *ByteTable) WriteHorizontalSequence(x, y *int, sequence []byte) {
+	_ = t.TryWriteHorizontalSequence(x, y, sequence)
+}
+
+// TryWriteHorizontalSequence is the equivalent of TryWriteVerticalSequence but for
+// horizontal sequences.
+//
+// See TryWriteVerticalSequence for more information.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell. (Never changes)
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - error: An error if the sequence could not be written.
+func (t *ByteTable) TryWriteHorizontalSequence(x, y *int, sequence []byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	} else if x == nil {
+		return errors.NewErrNilParameter("x")
+	} else if y == nil {
+		return errors.NewErrNilParameter("y")
+	} else if len(sequence) == 0 {
+		return nil
 	}
 
 	actualX, actualY := *x, *y
 
-	if actualY < 0 || actualY >= t.height || actualX >= t.width {
-		return
+	switch t.policy {
+	case BoundsWrap, BoundsClamp:
+		for i, cell := range sequence {
+			cellX, cellY, err := t.policy.Resolve(actualX+i, actualY, t.width, t.height)
+			if err != nil {
+				return err
+			}
+
+			t.table[cellY][cellX] = cell
+		}
+
+		*x = actualX + len(sequence)
+
+		return nil
+	case BoundsGrow:
+		if actualX >= 0 && actualY >= 0 {
+			t.grow(actualX+len(sequence), actualY+1)
+		}
+
+		fallthrough
+	case BoundsError:
+		err := CheckSpan(actualY, 1, t.height)
+		if err == nil {
+			err = CheckSpan(actualX, len(sequence), t.width)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if actualY < 0 || actualY >= t.height || actualX >= t.width || actualX+len(sequence) <= 0 {
+		return nil
 	}
 
 	if actualX < 0 {
 		sequence = sequence[-actualX:]
+		actualX = 0
+	}
 
-		*x = 0
-	} else if actualX+len(sequence) > t.width {
+	if actualX+len(sequence) > t.width {
 		sequence = sequence[:t.width-actualX]
 	}
 
 	copy(t.table[actualY][actualX:], sequence)
 
 	*x = actualX + len(sequence)
+
+	return nil
 }
 
 // FullTable returns the full table as a 2D slice of elements of type byte.
@@ -295,28 +547,169 @@ func (t ByteTable) IsYInBounds(y int) error {
 //   - x: The x-coordinate to write the table at.
 //   - y: The y-coordinate to write the table at.
 //
-// If the table is nil, x or y are nil, nothing happens.
-func (t ByteTable) WriteTableAt(table *ByteTable, x, y *int) {
-	if table == nil || x == nil || y == nil {
+// Cells of the given table that fall outside of the table are ignored. At the end of
+// the function, x and y are moved past the last column and row that could be written,
+// respectively.
+//
+// If the receiver, the table, x or y is nil, nothing happens.
+func (t *ByteTable) WriteTableAt(table *ByteTable, x, y *int) {
+	if t == nil || table == nil || x == nil || y == nil {
 		return
 	}
 
-	offsetX, offsetY := 0, 0
 	X, Y := *x, *y
 
-	for offsetY < table.height && Y+offsetY < t.height {
-		offsetX = 0
+	startX, startY := max(-X, 0), max(-Y, 0)
+	endX, endY := min(table.width, t.width-X), min(table.height, t.height-Y)
 
-		for offsetX < table.width && X+offsetX < t.width {
-			t.table[Y+offsetY][X+offsetX] = table.table[offsetY][offsetX]
-			offsetX++
+	if startX < endX {
+		for offsetY := startY; offsetY < endY; offsetY++ {
+			copy(t.table[Y+offsetY][X+startX:X+endX], table.table[offsetY][startX:endX])
 		}
+	}
+
+	endX, endY = max(endX, 0), max(endY, 0)
+
+	if endY == 0 {
+		endX = 0
+	}
+
+	*x += endX
+	*y += endY
+}
 
-		offsetY++
+// Bounds returns the rectangle covered by the table; that is, the rectangle whose
+// top-left cell is (0, 0) and whose size is the size of the table.
+//
+// Returns:
+//   - geom.Rect: The bounds of the table.
+func (t ByteTable) Bounds() geom.Rect {
+	return geom.Rect{
+		Width:  t.width,
+		Height: t.height,
 	}
+}
+
+// CellAtPoint is the equivalent of CellAt but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates of the cell.
+//
+// Returns:
+//   - byte: The cell at the given coordinates.
+func (t ByteTable) CellAtPoint(p geom.Point) byte {
+	return t.CellAt(p.X, p.Y)
+}
+
+// WriteAtPoint is the equivalent of WriteAt but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates of the cell.
+//   - cell: The cell to write to the table.
+func (t *ByteTable) WriteAtPoint(p geom.Point, cell byte) {
+	t.WriteAt(p.X, p.Y, cell)
+}
+
+// WriteVerticalSequenceAt is the equivalent of WriteVerticalSequence but takes the
+// starting cell as a point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - p: The starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - geom.Point: The cell right below the last cell that was written, or p if
+//     nothing was written.
+func (t *ByteTable) WriteVerticalSequenceAt(p geom.Point, sequence []byte) geom.Point {
+	t.WriteVerticalSequence(&p.X, &p.Y, sequence)
+
+	return p
+}
 
-	*x += offsetX
-	*y += offsetY
+// WriteHorizontalSequenceAt is the equivalent of WriteHorizontalSequence but takes the
+// starting cell as a point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - p: The starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - geom.Point: The cell right after the last cell that was written, or p if
+//     nothing was written.
+func (t *ByteTable) WriteHorizontalSequenceAt(p geom.Point, sequence []byte) geom.Point {
+	t.WriteHorizontalSequence(&p.X, &p.Y, sequence)
+
+	return p
+}
+
+// WriteTableAtPoint is the equivalent of WriteTableAt but takes the target cell as a
+// point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - table: The table to write to the table.
+//   - p: The cell to write the table at.
+//
+// Returns:
+//   - geom.Point: The moved point. See WriteTableAt for more information.
+func (t *ByteTable) WriteTableAtPoint(table *ByteTable, p geom.Point) geom.Point {
+	t.WriteTableAt(table, &p.X, &p.Y)
+
+	return p
+}
+
+// WriteTableIn copies the values from the given table into the given region of the
+// table. The top-left cell of the given table is written at the top-left cell of the
+// region; cells that fall outside of either the region or the table are ignored.
+//
+// Parameters:
+//   - table: The table to write to the table.
+//   - rect: The region to write the table in.
+//
+// If the receiver or the table is nil, nothing happens.
+func (t *ByteTable) WriteTableIn(table *ByteTable, rect geom.Rect) {
+	if t == nil || table == nil {
+		return
+	}
+
+	src := geom.Rect{
+		X:      rect.X,
+		Y:      rect.Y,
+		Width:  table.width,
+		Height: table.height,
+	}
+
+	clip := rect.Intersect(t.Bounds()).Intersect(src)
+	if clip.IsEmpty() {
+		return
+	}
+
+	for y := clip.Y; y < clip.Y+clip.Height; y++ {
+		copy(t.table[y][clip.X:clip.X+clip.Width], table.table[y-rect.Y][clip.X-rect.X:])
+	}
+}
+
+// FillRect sets every cell of the given region to the given value. Cells of the region
+// that fall outside of the table are ignored.
+//
+// Parameters:
+//   - rect: The region to fill.
+//   - cell: The value to write.
+//
+// If the receiver is nil, nothing happens.
+func (t *ByteTable) FillRect(rect geom.Rect, cell byte) {
+	if t == nil {
+		return
+	}
+
+	clip := rect.Intersect(t.Bounds())
+
+	for y := clip.Y; y < clip.Y+clip.Height; y++ {
+		row := t.table[y][clip.X : clip.X+clip.Width]
+
+		for x := range row {
+			row[x] = cell
+		}
+	}
 }
 	
 // ResizeWidth resizes the table to the given width.
@@ -377,10 +770,339 @@ func (t *ByteTable) ResizeHeight(new_height int) error {
 	} else if new_height < t.height {
 		t.table = t.table[:new_height]
 	} else {
-		t.table = append(t.table, make([][]byte, new_height-t.height)...)
+		for i := t.height; i < new_height; i++ {
+			t.table = append(t.table, make([]byte, t.width))
+		}
 	}
 
 	t.height = new_height
 
 	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The table is encoded as {"width":..,"height":..,"cells":[[..]]}.
+func (t ByteTable) MarshalJSON() ([]byte, error) {
+	cells := t.table
+	if cells == nil {
+		cells = [][]byte{}
+	}
+
+	return json.Marshal(TableJSON[byte]{
+		Width:  t.width,
+		Height: t.height,
+		Cells:  cells,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - error: If the data is not a valid table. See TableJSON.Validate.
+func (t *ByteTable) UnmarshalJSON(data []byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	}
+
+	var tj TableJSON[byte]
+
+	err := json.Unmarshal(data, &tj)
+	if err != nil {
+		return err
+	}
+
+	err = tj.Validate()
+	if err != nil {
+		return err
+	}
+
+	if tj.Cells == nil {
+		tj.Cells = [][]byte{}
+	}
+
+	t.table = tj.Cells
+	t.width = tj.Width
+	t.height = tj.Height
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The table is encoded as a versioned header followed by its cells, row by row,
+// in little-endian order. The error is always nil.
+func (t ByteTable) MarshalBinary() ([]byte, error) {
+	return MarshalBinaryCells(t.table, t.width, t.height)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - error: If the data is not a binary encoded table of byte cells.
+func (t *ByteTable) UnmarshalBinary(data []byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	}
+
+	cells, width, height, err := UnmarshalBinaryCells[byte](data)
+	if err != nil {
+		return err
+	}
+
+	t.table = cells
+	t.width = width
+	t.height = height
+
+	return nil
+}
+
+// RenderSVG draws the table as an SVG heatmap in which each cell is a rectangle
+// colored after its value.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the document could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (t ByteTable) RenderSVG(w io.Writer, opts SVGOptions) error {
+	return RenderHeatmap[byte](w, t, opts)
+}
+
+// WriteNetpbm writes the table as a Netpbm image whose pixels are the cells of the
+// table. See GrayCell for how cells are mapped to gray levels.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - format: The format of the image.
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil or the format is unknown.
+//   - error: Any error returned by w.
+func (t ByteTable) WriteNetpbm(w io.Writer, format NetpbmFormat) error {
+	return WriteGrayNetpbm[byte](w, t, format, Normalization{})
+}
+
+// WritePNG writes the table as a grayscale PNG image whose pixels are the cells of
+// the table. See GrayCell for how cells are mapped to gray levels.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w or by the PNG encoder.
+func (t ByteTable) WritePNG(w io.Writer) error {
+	return WriteGrayPNG[byte](w, t, Normalization{})
+}
+
+// ReadByteNetpbm reads a Netpbm image, in any of the PBM, PGM and PPM formats,
+// into a table whose cells are the pixels of the image. See GrayCell for how
+// gray levels are mapped to cells.
+//
+// Parameters:
+//   - r: The reader to read from.
+//
+// Returns:
+//   - *ByteTable: The table.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the image is malformed or any error returned by r.
+func ReadByteNetpbm(r io.Reader) (*ByteTable, error) {
+	cells, width, height, err := ReadGrayNetpbm[byte](r, Normalization{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ByteTable{table: cells, width: width, height: height}, nil
+}
+
+// ReadBytePNG reads a PNG image into a table whose cells are the pixels of the
+// image. See GrayCell for how gray levels are mapped to cells.
+//
+// Parameters:
+//   - r: The reader to read from.
+//
+// Returns:
+//   - *ByteTable: The table.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: Any error returned by the PNG decoder.
+func ReadBytePNG(r io.Reader) (*ByteTable, error) {
+	cells, width, height, err := ReadGrayPNG[byte](r, Normalization{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ByteTable{table: cells, width: width, height: height}, nil
+}
+
+// ByteTableFromImage creates a table whose cells are the pixels of the given image,
+// converted by GrayLevel. See FromImage.
+//
+// Parameters:
+//   - img: The image.
+//
+// Returns:
+//   - *ByteTable: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If img is nil.
+func ByteTableFromImage(img image.Image) (*ByteTable, error) {
+	t, err := FromImage(img, GrayLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ByteTable{table: t.FullTable(), width: t.Width(), height: t.Height()}, nil
+}
+
+// NewByteTableFromRows creates a new table whose cells are copies of the given rows.
+//
+// Parameters:
+//   - rows: The rows of the table, all of the same length.
+//
+// Returns:
+//   - *ByteTable: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the rows have different lengths.
+func NewByteTableFromRows(rows [][]byte) (*ByteTable, error) {
+	var width int
+
+	if len(rows) > 0 {
+		width = len(rows[0])
+	}
+
+	table := make([][]byte, 0, len(rows))
+
+	for i, row := range rows {
+		if len(row) != width {
+			return nil, errors.NewErrInvalidParameter("rows", fmt.Errorf("row %d has %d cells but row 0 has %d", i, len(row), width))
+		}
+
+		table = append(table, append(make([]byte, 0, width), row...))
+	}
+
+	return &ByteTable{
+		table:  table,
+		width:  width,
+		height: len(rows),
+	}, nil
+}
+
+// String implements the fmt.Stringer interface.
+//
+// It is equivalent to formatting the table with the %v verb.
+func (t ByteTable) String() string {
+	return fmt.Sprintf("%v", t)
+}
+
+// Format implements the fmt.Formatter interface.
+//
+// %v prints an aligned grid, %+v adds the row and column indices, and %#v prints a
+// call to NewByteTableFromRows. See FormatTable for more information.
+func (t ByteTable) Format(s fmt.State, verb rune) {
+	FormatTable(s, verb, t.table, t.width, "table.NewByteTableFromRows")
+}
+
+// ReadByteTableCSV reads delimited text into a new table, one record per row.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - opts: The reading options.
+//
+// Returns:
+//   - *ByteTable: The new table.
+//   - error: An error if the table could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - *ErrCell: If a field is not a valid byte, or if a record is
+//     shorter than the longest one and opts.PadRagged is false.
+//   - error: Any error returned by the underlying csv.Reader.
+func ReadByteTableCSV(r io.Reader, opts CSVOptions) (*ByteTable, error) {
+	cells, width, err := ReadCSVFunc(r, opts, parseByteTableCell)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ByteTable{
+		table:  cells,
+		width:  width,
+		height: len(cells),
+	}, nil
+}
+
+// WriteCSV writes the table as comma-separated values, one row per record.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error if the table could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - *ErrCell: If a cell could not be formatted.
+//   - error: Any error returned by w.
+func (t ByteTable) WriteCSV(w io.Writer) error {
+	return WriteCSVFunc(w, ',', t.table, formatByteTableCell)
+}
+
+// WriteTSV writes the table as tab-separated values, one row per record.
+//
+// See WriteCSV for more information.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error if the table could not be written.
+func (t ByteTable) WriteTSV(w io.Writer) error {
+	return WriteCSVFunc(w, '\t', t.table, formatByteTableCell)
+}
+
+// parseByteTableCell parses a field of delimited text into a cell.
+//
+// Parameters:
+//   - s: The field to parse.
+//
+// Returns:
+//   - byte: The cell.
+//   - error: An error if the field is not a valid byte.
+func parseByteTableCell(s string) (byte, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	return byte(v), err
+}
+
+// formatByteTableCell formats a cell into a field of delimited text.
+//
+// Parameters:
+//   - c: The cell to format.
+//
+// Returns:
+//   - string: The field.
+//   - error: An error if the cell could not be formatted.
+func formatByteTableCell(c byte) (string, error) {
+	return strconv.FormatUint(uint64(c), 10), nil
 }
\ No newline at end of file
@@ -0,0 +1,539 @@
+package table
+
+import (
+	"container/list"
+	"iter"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// DefaultChunkSize is the width and height of the chunks of a ChunkedTable when
+// the options do not specify them.
+const DefaultChunkSize = 64
+
+// ChunkKey identifies a chunk of a ChunkedTable. The chunk (X, Y) covers the cells
+// [X*width, (X+1)*width) x [Y*height, (Y+1)*height), where width and height are the
+// size of the chunks.
+type ChunkKey struct {
+	// X is the column of the chunk.
+	X int64
+
+	// Y is the row of the chunk.
+	Y int64
+}
+
+// ChunkedOptions are the options of a ChunkedTable.
+type ChunkedOptions[T any] struct {
+	// ChunkWidth is the width of every chunk. 0 means DefaultChunkSize.
+	ChunkWidth int
+
+	// ChunkHeight is the height of every chunk. 0 means DefaultChunkSize.
+	ChunkHeight int
+
+	// MaxChunks is the maximum number of chunks kept in memory. When a new chunk
+	// would exceed it, the least recently used chunk is evicted. 0 means unbounded.
+	MaxChunks int
+
+	// OnEvict, if not nil, is called with every chunk that is evicted; for instance,
+	// to persist it. The chunk is no longer used by the table afterwards.
+	OnEvict func(key ChunkKey, chunk *Table[T])
+
+	// Load, if not nil, is called whenever a chunk that is not in memory is accessed;
+	// for instance, to restore a persisted chunk. It returns false if there is no
+	// such chunk. Chunks of the wrong size are ignored.
+	Load func(key ChunkKey) (*Table[T], bool)
+}
+
+// chunkEntry is an element of the LRU list of a ChunkedTable.
+type chunkEntry[T any] struct {
+	// key is the key of the chunk.
+	key ChunkKey
+
+	// chunk is the content of the chunk.
+	chunk *Table[T]
+}
+
+// ChunkedTable is an unbounded table addressed by int64 coordinates. Cells are
+// stored in fixed-size chunks that are allocated on the first write and evicted,
+// least recently used first, when there are too many of them. Cells of chunks that
+// were never written read as the zero value of T.
+//
+// A ChunkedTable must be created with NewChunkedTable and is not safe for concurrent
+// use; note that reads may load and evict chunks as well.
+type ChunkedTable[T any] struct {
+	opts   ChunkedOptions[T]
+	chunks map[ChunkKey]*list.Element
+	lru    *list.List
+}
+
+// NewChunkedTable creates a new empty chunked table.
+//
+// Parameters:
+//   - opts: The options of the table.
+//
+// Returns:
+//   - *ChunkedTable[T]: The new table.
+//   - error: An error if the options are invalid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If a chunk size or the maximum number of chunks
+//     is negative.
+func NewChunkedTable[T any](opts ChunkedOptions[T]) (*ChunkedTable[T], error) {
+	if opts.ChunkWidth < 0 {
+		return nil, errors.NewErrInvalidParameter("opts.ChunkWidth", errors.NewErrGTE(0))
+	} else if opts.ChunkHeight < 0 {
+		return nil, errors.NewErrInvalidParameter("opts.ChunkHeight", errors.NewErrGTE(0))
+	} else if opts.MaxChunks < 0 {
+		return nil, errors.NewErrInvalidParameter("opts.MaxChunks", errors.NewErrGTE(0))
+	}
+
+	if opts.ChunkWidth == 0 {
+		opts.ChunkWidth = DefaultChunkSize
+	}
+
+	if opts.ChunkHeight == 0 {
+		opts.ChunkHeight = DefaultChunkSize
+	}
+
+	return &ChunkedTable[T]{
+		opts:   opts,
+		chunks: make(map[ChunkKey]*list.Element),
+		lru:    list.New(),
+	}, nil
+}
+
+// ChunkSize returns the width and height of the chunks.
+//
+// Returns:
+//   - int: The width of every chunk.
+//   - int: The height of every chunk.
+func (c ChunkedTable[T]) ChunkSize() (int, int) {
+	return c.opts.ChunkWidth, c.opts.ChunkHeight
+}
+
+// Len returns the number of chunks in memory.
+//
+// Returns:
+//   - int: The number of chunks in memory.
+func (c ChunkedTable[T]) Len() int {
+	return len(c.chunks)
+}
+
+// KeyOf returns the key of the chunk that contains the given cell.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - ChunkKey: The key of the chunk.
+func (c ChunkedTable[T]) KeyOf(x, y int64) ChunkKey {
+	key, _, _ := c.locate(x, y)
+
+	return key
+}
+
+// locate returns the chunk that contains the given cell and the position of the cell
+// within that chunk.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - ChunkKey: The key of the chunk.
+//   - int: The x-coordinate of the cell within the chunk.
+//   - int: The y-coordinate of the cell within the chunk.
+func (c ChunkedTable[T]) locate(x, y int64) (ChunkKey, int, int) {
+	w, h := int64(c.opts.ChunkWidth), int64(c.opts.ChunkHeight)
+
+	key := ChunkKey{
+		X: floorDiv(x, w),
+		Y: floorDiv(y, h),
+	}
+
+	return key, int(x - key.X*w), int(y - key.Y*h)
+}
+
+// floorDiv divides a by b, rounding towards negative infinity.
+//
+// Parameters:
+//   - a: The dividend.
+//   - b: The divisor. Assumed to be positive.
+//
+// Returns:
+//   - int64: The quotient.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b < 0 {
+		q--
+	}
+
+	return q
+}
+
+// chunk returns the chunk with the given key, loading it if needed, and marks it as
+// the most recently used.
+//
+// Parameters:
+//   - key: The key of the chunk.
+//   - create: Whether to allocate the chunk if it is neither in memory nor loaded.
+//
+// Returns:
+//   - *Table[T]: The chunk. Nil if the chunk does not exist and create is false.
+func (c *ChunkedTable[T]) chunk(key ChunkKey, create bool) *Table[T] {
+	if elem, ok := c.chunks[key]; ok {
+		c.lru.MoveToFront(elem)
+
+		return elem.Value.(*chunkEntry[T]).chunk
+	}
+
+	var chunk *Table[T]
+
+	if c.opts.Load != nil {
+		loaded, ok := c.opts.Load(key)
+		if ok && loaded != nil && loaded.Width() == c.opts.ChunkWidth && loaded.Height() == c.opts.ChunkHeight {
+			chunk = loaded
+		}
+	}
+
+	if chunk == nil {
+		if !create {
+			return nil
+		}
+
+		chunk, _ = NewTable[T](c.opts.ChunkWidth, c.opts.ChunkHeight)
+	}
+
+	c.chunks[key] = c.lru.PushFront(&chunkEntry[T]{
+		key:   key,
+		chunk: chunk,
+	})
+
+	for c.opts.MaxChunks > 0 && c.lru.Len() > c.opts.MaxChunks {
+		c.evict(c.lru.Back())
+	}
+
+	return chunk
+}
+
+// evict removes the given element from the table and calls OnEvict.
+//
+// Parameters:
+//   - elem: The element to evict. Assumed to be in the LRU list.
+func (c *ChunkedTable[T]) evict(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*chunkEntry[T])
+	delete(c.chunks, entry.key)
+
+	if c.opts.OnEvict != nil {
+		c.opts.OnEvict(entry.key, entry.chunk)
+	}
+}
+
+// Evict evicts the chunk with the given key, if it is in memory.
+//
+// Parameters:
+//   - key: The key of the chunk.
+//
+// Returns:
+//   - bool: True if the chunk was evicted, false otherwise.
+func (c *ChunkedTable[T]) Evict(key ChunkKey) bool {
+	if c == nil {
+		return false
+	}
+
+	elem, ok := c.chunks[key]
+	if !ok {
+		return false
+	}
+
+	c.evict(elem)
+
+	return true
+}
+
+// Flush evicts every chunk in memory, from the least to the most recently used. It
+// is typically used to persist the whole table through OnEvict.
+func (c *ChunkedTable[T]) Flush() {
+	if c == nil {
+		return
+	}
+
+	for c.lru.Len() > 0 {
+		c.evict(c.lru.Back())
+	}
+}
+
+// CellAt returns the cell at the given coordinates. Cells of chunks that do not
+// exist return the zero value of T; reading them does not allocate any chunk.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - T: The cell at the given coordinates.
+func (c *ChunkedTable[T]) CellAt(x, y int64) T {
+	if c == nil {
+		return *new(T)
+	}
+
+	key, localX, localY := c.locate(x, y)
+
+	chunk := c.chunk(key, false)
+	if chunk == nil {
+		return *new(T)
+	}
+
+	return chunk.CellAt(localX, localY)
+}
+
+// WriteAt writes a cell at the given coordinates, allocating its chunk if needed.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write.
+//
+// If the receiver is nil, nothing happens.
+func (c *ChunkedTable[T]) WriteAt(x, y int64, cell T) {
+	if c == nil {
+		return
+	}
+
+	key, localX, localY := c.locate(x, y)
+
+	c.chunk(key, true).WriteAt(localX, localY, cell)
+}
+
+// Chunks returns an iterator over the chunks in memory, from the most to the least
+// recently used. The table must not be modified during the iteration.
+//
+// Returns:
+//   - iter.Seq2[ChunkKey, *Table[T]]: The iterator. Never returns nil.
+func (c ChunkedTable[T]) Chunks() iter.Seq2[ChunkKey, *Table[T]] {
+	fn := func(yield func(ChunkKey, *Table[T]) bool) {
+		if c.lru == nil {
+			return
+		}
+
+		for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+			entry := elem.Value.(*chunkEntry[T])
+
+			if !yield(entry.key, entry.chunk) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// RowsIn returns an iterator that scans the given region row by row, like Row does
+// for a Table. The yielded slice is reused between rows.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left cell of the region.
+//   - y: The y-coordinate of the top-left cell of the region.
+//   - width: The width of the region. Negative values are treated as 0.
+//   - height: The height of the region. Negative values are treated as 0.
+//
+// Returns:
+//   - iter.Seq[[]T]: The iterator. Never returns nil.
+func (c *ChunkedTable[T]) RowsIn(x, y int64, width, height int) iter.Seq[[]T] {
+	fn := func(yield func([]T) bool) {
+		if c == nil || width <= 0 || height <= 0 {
+			return
+		}
+
+		row := make([]T, width)
+
+		for offsetY := 0; offsetY < height; offsetY++ {
+			for offsetX := 0; offsetX < width; {
+				key, localX, localY := c.locate(x+int64(offsetX), y+int64(offsetY))
+				n := min(c.opts.ChunkWidth-localX, width-offsetX)
+
+				chunk := c.chunk(key, false)
+				if chunk == nil {
+					clear(row[offsetX : offsetX+n])
+				} else {
+					copy(row[offsetX:offsetX+n], chunk.FullTable()[localY][localX:])
+				}
+
+				offsetX += n
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// CellsIn returns an iterator that scans the given region cell by cell, row by row,
+// like Cell does for a Table.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left cell of the region.
+//   - y: The y-coordinate of the top-left cell of the region.
+//   - width: The width of the region. Negative values are treated as 0.
+//   - height: The height of the region. Negative values are treated as 0.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (c *ChunkedTable[T]) CellsIn(x, y int64, width, height int) iter.Seq[T] {
+	fn := func(yield func(T) bool) {
+		for row := range c.RowsIn(x, y, width, height) {
+			for _, cell := range row {
+				if !yield(cell) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Region copies the given region into a new table.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left cell of the region.
+//   - y: The y-coordinate of the top-left cell of the region.
+//   - width: The width of the region. Negative values are treated as 0.
+//   - height: The height of the region. Negative values are treated as 0.
+//
+// Returns:
+//   - *Table[T]: The copy. Never returns nil.
+func (c *ChunkedTable[T]) Region(x, y int64, width, height int) *Table[T] {
+	table, _ := NewTable[T](max(width, 0), max(height, 0))
+
+	var offsetY int
+
+	for row := range c.RowsIn(x, y, width, height) {
+		var offsetX int
+
+		table.WriteHorizontalSequence(&offsetX, &offsetY, row)
+		offsetY++
+	}
+
+	return table
+}
+
+// WriteRegion copies the given table into the chunked table, with its top-left cell
+// at the given coordinates.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left cell of the region.
+//   - y: The y-coordinate of the top-left cell of the region.
+//   - src: The table to copy.
+//
+// If the receiver or src is nil, nothing happens.
+func (c *ChunkedTable[T]) WriteRegion(x, y int64, src Reader[T]) {
+	if c == nil || src == nil {
+		return
+	}
+
+	width, height := src.Width(), src.Height()
+
+	for offsetY := 0; offsetY < height; offsetY++ {
+		for offsetX := 0; offsetX < width; {
+			key, localX, localY := c.locate(x+int64(offsetX), y+int64(offsetY))
+			n := min(c.opts.ChunkWidth-localX, width-offsetX)
+
+			chunk := c.chunk(key, true)
+
+			for i := range n {
+				chunk.WriteAt(localX+i, localY, src.CellAt(offsetX+i, offsetY))
+			}
+
+			offsetX += n
+		}
+	}
+}
+
+// ChunkWindow is a fixed-size region of a ChunkedTable. Coordinates given to a
+// window are relative to its top-left cell and accesses never leave it, which lets
+// a chunked table be used wherever a ReadWriter is expected.
+type ChunkWindow[T any] struct {
+	src           *ChunkedTable[T]
+	x, y          int64
+	width, height int
+}
+
+// _ asserts that every instance of ChunkWindow implements the ReadWriter interface.
+func _[T any]() {
+	var _ ReadWriter[T] = (*ChunkWindow[T])(nil)
+}
+
+// Window returns a window over the given region of the table.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left cell of the region.
+//   - y: The y-coordinate of the top-left cell of the region.
+//   - width: The width of the region. Negative values are treated as 0.
+//   - height: The height of the region. Negative values are treated as 0.
+//
+// Returns:
+//   - *ChunkWindow[T]: The window. Never returns nil.
+func (c *ChunkedTable[T]) Window(x, y int64, width, height int) *ChunkWindow[T] {
+	return &ChunkWindow[T]{
+		src:    c,
+		x:      x,
+		y:      y,
+		width:  max(width, 0),
+		height: max(height, 0),
+	}
+}
+
+// Origin returns the coordinates, in the chunked table, of the top-left cell of the
+// window.
+//
+// Returns:
+//   - int64: The x-coordinate of the top-left cell.
+//   - int64: The y-coordinate of the top-left cell.
+func (w ChunkWindow[T]) Origin() (int64, int64) {
+	return w.x, w.y
+}
+
+// Width implements the Reader interface.
+func (w ChunkWindow[T]) Width() int {
+	return w.width
+}
+
+// Height implements the Reader interface.
+func (w ChunkWindow[T]) Height() int {
+	return w.height
+}
+
+// CellAt implements the Reader interface.
+func (w ChunkWindow[T]) CellAt(x, y int) T {
+	if x < 0 || x >= w.width || y < 0 || y >= w.height {
+		return *new(T)
+	}
+
+	return w.src.CellAt(w.x+int64(x), w.y+int64(y))
+}
+
+// Cell implements the Reader interface.
+func (w ChunkWindow[T]) Cell() iter.Seq[T] {
+	return w.src.CellsIn(w.x, w.y, w.width, w.height)
+}
+
+// Row implements the Reader interface.
+//
+// The yielded slice is reused between rows.
+func (w ChunkWindow[T]) Row() iter.Seq[[]T] {
+	return w.src.RowsIn(w.x, w.y, w.width, w.height)
+}
+
+// WriteAt implements the Writer interface.
+func (w ChunkWindow[T]) WriteAt(x, y int, cell T) {
+	if x < 0 || x >= w.width || y < 0 || y >= w.height {
+		return
+	}
+
+	w.src.WriteAt(w.x+int64(x), w.y+int64(y), cell)
+}
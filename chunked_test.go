@@ -0,0 +1,200 @@
+package table_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestChunkedTableKeyOf(t *testing.T) {
+	c := must(table.NewChunkedTable(table.ChunkedOptions[int]{ChunkWidth: 2, ChunkHeight: 3}))
+
+	tests := []struct {
+		x, y int64
+		want table.ChunkKey
+	}{
+		{0, 0, table.ChunkKey{X: 0, Y: 0}},
+		{1, 2, table.ChunkKey{X: 0, Y: 0}},
+		{2, 3, table.ChunkKey{X: 1, Y: 1}},
+		{-1, -1, table.ChunkKey{X: -1, Y: -1}},
+		{-2, -3, table.ChunkKey{X: -1, Y: -1}},
+		{-3, -4, table.ChunkKey{X: -2, Y: -2}},
+	}
+
+	for _, tt := range tests {
+		if got := c.KeyOf(tt.x, tt.y); got != tt.want {
+			t.Errorf("KeyOf(%d, %d) = %+v, want %+v", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func TestChunkedTableEviction(t *testing.T) {
+	var evicted []table.ChunkKey
+
+	stored := make(map[table.ChunkKey]*table.Table[int])
+
+	opts := table.ChunkedOptions[int]{
+		ChunkWidth:  2,
+		ChunkHeight: 2,
+		MaxChunks:   2,
+		OnEvict: func(key table.ChunkKey, chunk *table.Table[int]) {
+			evicted = append(evicted, key)
+			stored[key] = chunk
+		},
+		Load: func(key table.ChunkKey) (*table.Table[int], bool) {
+			chunk, ok := stored[key]
+			delete(stored, key)
+
+			return chunk, ok
+		},
+	}
+
+	c := must(table.NewChunkedTable(opts))
+
+	c.WriteAt(0, 0, 1)
+	c.WriteAt(2, 0, 2)
+
+	// Reading the first chunk makes it the most recently used one, so the second
+	// chunk is evicted by the third.
+	if got := c.CellAt(0, 0); got != 1 {
+		t.Fatalf("CellAt(0, 0) = %d, want 1", got)
+	}
+
+	c.WriteAt(4, 0, 3)
+
+	if want := []table.ChunkKey{{X: 1, Y: 0}}; !reflect.DeepEqual(evicted, want) {
+		t.Fatalf("evicted %v, want %v", evicted, want)
+	}
+
+	var keys []table.ChunkKey
+
+	for key := range c.Chunks() {
+		keys = append(keys, key)
+	}
+
+	if want := []table.ChunkKey{{X: 2, Y: 0}, {X: 0, Y: 0}}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Chunks yields %v, want %v", keys, want)
+	}
+
+	// Reading the evicted chunk loads it back and evicts the least recently used one.
+	if got := c.CellAt(2, 0); got != 2 {
+		t.Errorf("CellAt(2, 0) = %d after eviction, want 2", got)
+	}
+
+	if want := []table.ChunkKey{{X: 1, Y: 0}, {X: 0, Y: 0}}; !reflect.DeepEqual(evicted, want) {
+		t.Errorf("evicted %v, want %v", evicted, want)
+	}
+
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len = %d, want 2", got)
+	}
+
+	c.Flush()
+
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len = %d after Flush, want 0", got)
+	}
+
+	if got := len(stored); got != 3 {
+		t.Errorf("%d chunks stored after Flush, want 3", got)
+	}
+}
+
+func TestChunkedTableReadsDoNotAllocate(t *testing.T) {
+	loads := 0
+
+	opts := table.ChunkedOptions[int]{
+		ChunkWidth:  2,
+		ChunkHeight: 2,
+		Load: func(key table.ChunkKey) (*table.Table[int], bool) {
+			loads++
+
+			// Chunks of the wrong size are ignored.
+			return must(table.NewTable[int](3, 3)), true
+		},
+	}
+
+	c := must(table.NewChunkedTable(opts))
+
+	if got := c.CellAt(-5, 7); got != 0 {
+		t.Errorf("CellAt(-5, 7) = %d, want 0", got)
+	}
+
+	for range c.RowsIn(-1, -1, 3, 3) {
+	}
+
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len = %d after reads, want 0", got)
+	}
+
+	if loads == 0 {
+		t.Error("Load was never called")
+	}
+}
+
+func TestChunkedTableRowsIn(t *testing.T) {
+	c := must(table.NewChunkedTable(table.ChunkedOptions[int]{ChunkWidth: 2, ChunkHeight: 2}))
+
+	// The region spans the four chunks around the origin, and a fifth one that is
+	// never written.
+	writes := map[[2]int64]int{
+		{-3, -2}: 1,
+		{-1, -1}: 2,
+		{0, -1}:  3,
+		{-1, 0}:  4,
+		{1, 1}:   5,
+		{2, 1}:   6,
+	}
+
+	for p, cell := range writes {
+		c.WriteAt(p[0], p[1], cell)
+	}
+
+	want := [][]int{
+		{1, 0, 0, 0, 0, 0},
+		{0, 0, 2, 3, 0, 0},
+		{0, 0, 4, 0, 0, 0},
+		{0, 0, 0, 0, 5, 6},
+	}
+
+	var got [][]int
+
+	for row := range c.RowsIn(-3, -2, 6, 4) {
+		got = append(got, append([]int(nil), row...))
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RowsIn yields %v, want %v", got, want)
+	}
+
+	if region := c.Region(-3, -2, 6, 4).FullTable(); !reflect.DeepEqual(region, want) {
+		t.Errorf("Region is %v, want %v", region, want)
+	}
+
+	var cells []int
+
+	for cell := range c.CellsIn(-1, -1, 2, 2) {
+		cells = append(cells, cell)
+	}
+
+	if want := []int{2, 3, 4, 0}; !reflect.DeepEqual(cells, want) {
+		t.Errorf("CellsIn yields %v, want %v", cells, want)
+	}
+}
+
+func TestChunkedTableWriteRegion(t *testing.T) {
+	c := must(table.NewChunkedTable(table.ChunkedOptions[int]{ChunkWidth: 2, ChunkHeight: 2}))
+
+	src := must(table.NewTableFromRows([][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}))
+
+	c.WriteRegion(-1, -1, src)
+
+	if got := c.Len(); got != 4 {
+		t.Errorf("Len = %d, want 4", got)
+	}
+
+	if got := c.Region(-1, -1, 3, 3).FullTable(); !reflect.DeepEqual(got, src.FullTable()) {
+		t.Errorf("Region is %v, want %v", got, src.FullTable())
+	}
+}
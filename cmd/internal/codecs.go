@@ -0,0 +1,185 @@
+package internal
+
+import (
+	"strings"
+)
+
+// cellCodec is the Go code that converts a cell of a given type to and from
+// a field of delimited text.
+type cellCodec struct {
+	// parse is the body of a func(s string) (T, error).
+	parse string
+
+	// format is the body of a func(c T) (string, error).
+	format string
+
+	// imports are the standard packages used by parse and format.
+	imports []string
+}
+
+// intCodec returns the codec of a signed integer type.
+//
+// Parameters:
+//   - type_name: The name of the type.
+//   - bit_size: The size, in bits, of the type. 0 for int.
+//
+// Returns:
+//   - cellCodec: The codec.
+func intCodec(type_name, bit_size string) cellCodec {
+	return cellCodec{
+		parse:   "\tv, err := strconv.ParseInt(s, 10, " + bit_size + ")\n\treturn " + type_name + "(v), err",
+		format:  "\treturn strconv.FormatInt(int64(c), 10), nil",
+		imports: []string{"strconv"},
+	}
+}
+
+// uintCodec returns the codec of an unsigned integer type.
+//
+// Parameters:
+//   - type_name: The name of the type.
+//   - bit_size: The size, in bits, of the type. 0 for uint and uintptr, whose
+//     size depends on the platform.
+//
+// Returns:
+//   - cellCodec: The codec.
+func uintCodec(type_name, bit_size string) cellCodec {
+	return cellCodec{
+		parse:   "\tv, err := strconv.ParseUint(s, 10, " + bit_size + ")\n\treturn " + type_name + "(v), err",
+		format:  "\treturn strconv.FormatUint(uint64(c), 10), nil",
+		imports: []string{"strconv"},
+	}
+}
+
+// builtinCodecs are the codecs of the predeclared types that have a natural
+// textual representation.
+var builtinCodecs = map[string]cellCodec{
+	"bool": {
+		parse:   "\treturn strconv.ParseBool(s)",
+		format:  "\treturn strconv.FormatBool(c), nil",
+		imports: []string{"strconv"},
+	},
+	"string": {
+		parse:  "\treturn s, nil",
+		format: "\treturn c, nil",
+	},
+	"rune": {
+		parse: strings.Join([]string{
+			"\tif s == \"\" {",
+			"\t\treturn 0, nil",
+			"\t}",
+			"",
+			"\tr, size := utf8.DecodeRuneInString(s)",
+			"\tif size != len(s) || (r == utf8.RuneError && size == 1) {",
+			"\t\treturn 0, fmt.Errorf(\"%q is not a single rune\", s)",
+			"\t}",
+			"",
+			"\treturn r, nil",
+		}, "\n"),
+		format: strings.Join([]string{
+			"\tif c == 0 {",
+			"\t\treturn \"\", nil",
+			"\t}",
+			"",
+			"\treturn string(c), nil",
+		}, "\n"),
+		imports: []string{"fmt", "unicode/utf8"},
+	},
+	"int":     intCodec("int", "0"),
+	"int8":    intCodec("int8", "8"),
+	"int16":   intCodec("int16", "16"),
+	"int32":   intCodec("int32", "32"),
+	"int64":   intCodec("int64", "64"),
+	"uint":    uintCodec("uint", "0"),
+	"uint8":   uintCodec("uint8", "8"),
+	"byte":    uintCodec("byte", "8"),
+	"uint16":  uintCodec("uint16", "16"),
+	"uint32":  uintCodec("uint32", "32"),
+	"uint64":  uintCodec("uint64", "64"),
+	"uintptr": uintCodec("uintptr", "0"),
+	"float32": {
+		parse:   "\tv, err := strconv.ParseFloat(s, 32)\n\treturn float32(v), err",
+		format:  "\treturn strconv.FormatFloat(float64(c), 'g', -1, 32), nil",
+		imports: []string{"strconv"},
+	},
+	"float64": {
+		parse:   "\treturn strconv.ParseFloat(s, 64)",
+		format:  "\treturn strconv.FormatFloat(c, 'g', -1, 64), nil",
+		imports: []string{"strconv"},
+	},
+	"complex64": {
+		parse:   "\tv, err := strconv.ParseComplex(s, 64)\n\treturn complex64(v), err",
+		format:  "\treturn strconv.FormatComplex(complex128(c), 'g', -1, 64), nil",
+		imports: []string{"strconv"},
+	},
+	"complex128": {
+		parse:   "\treturn strconv.ParseComplex(s, 128)",
+		format:  "\treturn strconv.FormatComplex(c, 'g', -1, 128), nil",
+		imports: []string{"strconv"},
+	},
+}
+
+// textCodec returns the codec of a type whose pointer implements the
+// encoding.TextUnmarshaler interface and that implements the encoding.TextMarshaler
+// interface.
+//
+// Parameters:
+//   - type_name: The name of the type.
+//
+// Returns:
+//   - cellCodec: The codec.
+func textCodec(type_name string) cellCodec {
+	return cellCodec{
+		parse:  "\tvar c " + type_name + "\n\n\terr := c.UnmarshalText([]byte(s))\n\treturn c, err",
+		format: "\tb, err := c.MarshalText()\n\treturn string(b), err",
+	}
+}
+
+// jsonCodec is the Go code that converts the cells of a type without a JSON
+// encoding of its own to and from a type with one.
+type jsonCodec struct {
+	// cell is the type the cells are encoded as.
+	cell string
+
+	// encode is the name of the func(c T) cell of the table package.
+	encode string
+
+	// decode is the name of the func(v cell) T of the table package.
+	decode string
+}
+
+// jsonCodecs are the JSON codecs of the predeclared types that encoding/json does
+// not support, by cell type.
+var jsonCodecs = map[string]jsonCodec{
+	"complex64":  {cell: "[2]float32", encode: "Complex64JSON", decode: "Complex64FromJSON"},
+	"complex128": {cell: "[2]float64", encode: "Complex128JSON", decode: "Complex128FromJSON"},
+	"error":      {cell: "*string", encode: "ErrorJSON", decode: "ErrorFromJSON"},
+}
+
+// binaryCells are the cell types that have a binary encoding. See table.BinaryCell.
+var binaryCells = map[string]bool{
+	"bool": true, "byte": true, "rune": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+}
+
+// heatmapCells are the cell types whose tables are drawn as heatmaps. See
+// table.HeatmapCell; rune tables are drawn as text instead.
+var heatmapCells = map[string]bool{
+	"byte": true, "uintptr": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
+	"float32": true, "float64": true,
+}
+
+// grayCells are the cell types whose tables are written as grayscale images. See
+// table.GrayCell.
+var grayCells = map[string]bool{
+	"bool": true, "byte": true, "uint8": true, "uint16": true, "float32": true, "float64": true,
+}
+
+// imageCells are the functions of the table package that convert colors to cells,
+// by the cell types whose tables can be created from images.
+var imageCells = map[string]string{
+	"bool": "IsOpaque", "byte": "GrayLevel", "uint8": "GrayLevel", "uint16": "Gray16Level",
+}
@@ -16,6 +16,8 @@ var (
 	GenericsFlag *gcgen.GenericsSignVal
 
 	TypeNameFlag *string
+
+	TextFlag *bool
 )
 
 func init() {
@@ -25,6 +27,8 @@ func init() {
 
 	TypeListFlag = gcgen.NewTypeListFlag("type", true, 1, "The type of each table's cell.")
 	GenericsFlag = gcgen.NewGenericsSignFlag("g", false, 1)
+
+	TextFlag = flag.Bool("text", false, "Whether the cell type implements encoding.TextMarshaler and encoding.TextUnmarshaler.")
 }
 
 func Parse() (string, error) {
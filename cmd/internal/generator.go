@@ -2,6 +2,8 @@ package internal
 
 import (
 	"log"
+	"slices"
+	"strings"
 
 	gcgen "github.com/PlayerR9/go-commons/generator"
 )
@@ -50,6 +52,101 @@ func init() {
 		return nil
 	})
 
+	tmp.AddDoFunc(func(data *GenData) error {
+		data.StdImports = []string{"encoding/json", "fmt", "iter"}
+		data.Imports = []string{
+			"github.com/PlayerR9/go-commons/errors",
+			"github.com/PlayerR9/go-commons/ints",
+		}
+
+		if data.PackageName != "table" {
+			data.TablePkg = "table."
+			data.Imports = append(data.Imports, "github.com/PlayerR9/table")
+		}
+
+		data.Imports = append(data.Imports, "github.com/PlayerR9/table/geom")
+
+		return nil
+	})
+
+	tmp.AddDoFunc(func(data *GenData) error {
+		var codec cellCodec
+		var ok bool
+
+		if TextFlag != nil && *TextFlag {
+			codec, ok = textCodec(data.CellType), true
+		} else {
+			codec, ok = builtinCodecs[data.CellType]
+		}
+
+		if !ok || data.GenericsSign != "" {
+			return nil
+		}
+
+		data.CSV = true
+		data.ParseCell = codec.parse
+		data.FormatCell = codec.format
+
+		data.addStdImports("io")
+		data.addStdImports(codec.imports...)
+
+		return nil
+	})
+
+	tmp.AddDoFunc(func(data *GenData) error {
+		codec, ok := jsonCodecs[data.CellType]
+		if !ok || data.GenericsSign != "" {
+			return nil
+		}
+
+		data.JSONCell = codec.cell
+		data.JSONEncode = codec.encode
+		data.JSONDecode = codec.decode
+
+		return nil
+	})
+
+	tmp.AddDoFunc(func(data *GenData) error {
+		data.Binary = binaryCells[data.CellType] && data.GenericsSign == ""
+
+		return nil
+	})
+
+	tmp.AddDoFunc(func(data *GenData) error {
+		data.Heatmap = heatmapCells[data.CellType] && data.GenericsSign == ""
+
+		if data.Heatmap {
+			data.addStdImports("io")
+		}
+
+		return nil
+	})
+
+	tmp.AddDoFunc(func(data *GenData) error {
+		data.Gray = grayCells[data.CellType] && data.GenericsSign == ""
+
+		if data.Gray {
+			data.GrayName = strings.TrimSuffix(data.TypeName, "Table")
+			data.addStdImports("io")
+		}
+
+		return nil
+	})
+
+	tmp.AddDoFunc(func(data *GenData) error {
+		if data.GenericsSign != "" {
+			return nil
+		}
+
+		data.ImageCell = imageCells[data.CellType]
+
+		if data.ImageCell != "" {
+			data.addStdImports("image")
+		}
+
+		return nil
+	})
+
 	Generator = tmp
 }
 
@@ -72,6 +169,69 @@ type GenData struct {
 
 	// ZeroValue is the zero value of the cell type.
 	ZeroValue string
+
+	// StdImports are the standard packages imported by the generated code.
+	StdImports []string
+
+	// Imports are the other packages imported by the generated code.
+	Imports []string
+
+	// TablePkg is the qualifier of the identifiers of the table package. It is
+	// empty when the code is generated within the table package itself.
+	TablePkg string
+
+	// CSV is true if the delimited text methods are generated.
+	CSV bool
+
+	// ParseCell is the body of the function that parses a field into a cell.
+	ParseCell string
+
+	// FormatCell is the body of the function that formats a cell into a field.
+	FormatCell string
+
+	// JSONCell is the type the cells are converted to before being encoded as JSON.
+	// It is empty when the cells are encoded as they are.
+	JSONCell string
+
+	// JSONEncode is the function of the table package that converts a cell to
+	// JSONCell.
+	JSONEncode string
+
+	// JSONDecode is the function of the table package that converts a JSONCell back
+	// to a cell.
+	JSONDecode string
+
+	// Binary is true if the binary encoding methods are generated.
+	Binary bool
+
+	// Heatmap is true if the RenderSVG method, which draws a heatmap, is generated.
+	Heatmap bool
+
+	// Gray is true if the methods and functions that write and read grayscale images
+	// are generated.
+	Gray bool
+
+	// GrayName is the name of the type in the functions that read grayscale images;
+	// that is, TypeName without its "Table" suffix.
+	GrayName string
+
+	// ImageCell is the function of the table package that converts a color to a
+	// cell. If empty, the FromImage function is not generated.
+	ImageCell string
+}
+
+// addStdImports adds standard packages to the imports of the generated code,
+// keeping them sorted and without duplicates.
+//
+// Parameters:
+//   - pkgs: The packages to add.
+func (g *GenData) addStdImports(pkgs ...string) {
+	for _, pkg := range pkgs {
+		pos, ok := slices.BinarySearch(g.StdImports, pkg)
+		if !ok {
+			g.StdImports = slices.Insert(g.StdImports, pos, pkg)
+		}
+	}
 }
 
 // SetPackageName implements the go_generator.Generater interface.
@@ -89,18 +249,31 @@ const templ = `// Code generated by go:generate. DO NOT EDIT.
 package {{ .PackageName }}
 
 import (
-	"iter"	
-
-	"github.com/PlayerR9/go-commons/errors"
-	"github.com/PlayerR9/go-commons/ints"
+{{- range .StdImports }}
+	"{{ . }}"
+{{- end }}
+{{ range .Imports }}
+	"{{ . }}"
+{{- end }}
 )
 
 // {{ .TypeName }}{{ .GenericsSign }} represents a table of cells that can be drawn to the screen.
+//
+// Every method that modifies the table has a pointer receiver, since writes may grow
+// the table; see {{ .TablePkg }}BoundsGrow.
 type {{ .TypeName }}{{ .GenericsSign }} struct {
 	table         [][]{{ .CellType }}
 	width, height int
+	policy        {{ .TablePkg }}BoundsPolicy
 }
-
+{{ if .GenericsSign }}
+// _ asserts that every instance of {{ .TypeName }} implements the {{ .TablePkg }}ReadWriter interface.
+func _{{ .GenericsSign }}() {
+	var _ {{ .TablePkg }}ReadWriter[{{ .CellType }}] = (*{{ .TypeSig }})(nil)
+}
+{{ else }}
+var _ {{ .TablePkg }}ReadWriter[{{ .CellType }}] = (*{{ .TypeSig }})(nil)
+{{ end }}
 // New{{ .TypeName }} creates a new table of type {{ .CellType }} with the given width and height.
 // Negative parameters are treated as absolute values.
 //
@@ -180,8 +353,13 @@ func (t {{ .TypeSig }}) Row() iter.Seq[[]{{ .CellType }}] {
 
 // Cleanup is a method that cleans up the table.
 //
-// It sets all cells in the table to the zero value of type {{ .CellType }}.
-func (t {{ .TypeSig }}) Cleanup() {
+// It sets all cells in the table to the zero value of type {{ .CellType }}. If the
+// receiver is nil, nothing happens.
+func (t *{{ .TypeSig }}) Cleanup() {
+	if t == nil {
+		return
+	}
+
 	for i := 0; i < t.height; i++ {
 		for j := 0; j < t.width; j++ {
 			t.table[i][j] = {{ .ZeroValue }}
@@ -205,23 +383,105 @@ func (t {{ .TypeSig }}) Height() int {
 	return t.height
 }
 
-// WriteAt writes a cell to the table at the given coordinates. However, out-of-bounds
-// coordinates do nothing.
+// BoundsPolicy returns the policy the table uses for out-of-bounds coordinates.
+//
+// Returns:
+//   - {{ .TablePkg }}BoundsPolicy: The bounds policy. Defaults to {{ .TablePkg }}BoundsClip.
+func (t {{ .TypeSig }}) BoundsPolicy() {{ .TablePkg }}BoundsPolicy {
+	return t.policy
+}
+
+// SetBoundsPolicy sets the policy the table uses for out-of-bounds coordinates. It
+// is honoured by CellAt, WriteAt, the sequence writers and their variants.
+//
+// Parameters:
+//   - policy: The new bounds policy.
+//
+// Returns:
+//   - error: An error if the policy could not be set.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the policy is not valid.
+//   - errors.NilReceiver: If the table is nil.
+func (t *{{ .TypeSig }}) SetBoundsPolicy(policy {{ .TablePkg }}BoundsPolicy) error {
+	if t == nil {
+		return errors.NilReceiver
+	} else if !policy.IsValid() {
+		return errors.NewErrInvalidParameter("policy", fmt.Errorf("unknown bounds policy %v", policy))
+	}
+
+	t.policy = policy
+
+	return nil
+}
+
+// grow grows the table so that it has at least the given number of columns and rows.
+//
+// Parameters:
+//   - width: The minimum width of the table.
+//   - height: The minimum height of the table.
+func (t *{{ .TypeSig }}) grow(width, height int) {
+	if width > t.width {
+		_ = t.ResizeWidth(width)
+	}
+
+	if height > t.height {
+		_ = t.ResizeHeight(height)
+	}
+}
+
+// WriteAt writes a cell to the table at the given coordinates. Out-of-bounds
+// coordinates are handled according to the bounds policy of the table; by default,
+// they do nothing.
 //
 // Parameters:
 //   - x: The x-coordinate of the cell.
 //   - y: The y-coordinate of the cell.
 //   - cell: The cell to write to the table.
-func (t {{ .TypeSig }}) WriteAt(x, y int, cell {{ .CellType }}) {
-	if x < 0 || x >= t.width || y < 0 || y >= t.height {
-		return
+//
+// See TryWriteAt for a variant that reports out-of-bounds coordinates.
+func (t *{{ .TypeSig }}) WriteAt(x, y int, cell {{ .CellType }}) {
+	_ = t.TryWriteAt(x, y, cell)
+}
+
+// TryWriteAt is the equivalent of WriteAt but reports the coordinates the bounds
+// policy rejects.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write to the table.
+//
+// Returns:
+//   - error: An error if the cell could not be written.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - *ints.ErrOutOfBounds: If the coordinates are out of bounds and the policy is
+//     either {{ .TablePkg }}BoundsError or {{ .TablePkg }}BoundsGrow, or if the table is empty and the
+//     policy is either {{ .TablePkg }}BoundsWrap or {{ .TablePkg }}BoundsClamp.
+func (t *{{ .TypeSig }}) TryWriteAt(x, y int, cell {{ .CellType }}) error {
+	if t == nil {
+		return errors.NilReceiver
+	}
+
+	if t.policy == {{ .TablePkg }}BoundsGrow && x >= 0 && y >= 0 {
+		t.grow(x+1, y+1)
+	}
+
+	actualX, actualY, err := t.policy.Resolve(x, y, t.width, t.height)
+	if err == nil {
+		t.table[actualY][actualX] = cell
+	} else if t.policy == {{ .TablePkg }}BoundsClip {
+		err = nil
 	}
 
-	t.table[y][x] = cell
+	return err
 }
 
-// CellAt returns the cell at the given coordinates in the table. However, out-of-bounds
-// coordinates return {{ .ZeroValue }}.
+// CellAt returns the cell at the given coordinates in the table. Out-of-bounds
+// coordinates are handled according to the bounds policy of the table; by default,
+// they return {{ .ZeroValue }}.
 //
 // Parameters:
 //   - x: The x-coordinate of the cell.
@@ -229,14 +489,43 @@ func (t {{ .TypeSig }}) WriteAt(x, y int, cell {{ .CellType }}) {
 //
 // Returns:
 //   - {{ .CellType }}: The cell at the given coordinates.
+//
+// See TryCellAt for a variant that reports out-of-bounds coordinates.
 func (t {{ .TypeSig }}) CellAt(x, y int) {{ .CellType }} {
-	if x < 0 || x >= t.width || y < 0 || y >= t.height {
+	x, y, err := t.policy.Resolve(x, y, t.width, t.height)
+	if err != nil {
 		return {{ .ZeroValue }}
 	} else {
 		return t.table[y][x]
 	}
 }
 
+// TryCellAt is the equivalent of CellAt but reports the coordinates the bounds
+// policy rejects.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - {{ .CellType }}: The cell at the given coordinates. {{ .ZeroValue }} if the cell could not be read.
+//   - error: An error if the cell could not be read.
+//
+// Errors:
+//   - *ints.ErrOutOfBounds: If the coordinates are out of bounds and the policy is
+//     either {{ .TablePkg }}BoundsError or {{ .TablePkg }}BoundsGrow, or if the table is empty and the
+//     policy is either {{ .TablePkg }}BoundsWrap or {{ .TablePkg }}BoundsClamp.
+func (t {{ .TypeSig }}) TryCellAt(x, y int) ({{ .CellType }}, error) {
+	actualX, actualY, err := t.policy.Resolve(x, y, t.width, t.height)
+	if err == nil {
+		return t.table[actualY][actualX], nil
+	} else if t.policy == {{ .TablePkg }}BoundsClip {
+		return {{ .ZeroValue }}, nil
+	} else {
+		return {{ .ZeroValue }}, err
+	}
+}
+
 // WriteVerticalSequence is a function that writes the specified values to the table
 // starting from the specified coordinates (top = 0, 0) and continuing down the
 // table in the vertical direction until either the sequence is exhausted or
@@ -271,22 +560,88 @@ func (t {{ .TypeSig }}) CellAt(x, y int) {{ .CellType }} {
 //
 // As you can see, the 'g' value was ignored as it would be out-of-bounds.
 // Finally, if either x or y is nil, the function does nothing.
-func (t {{ .TypeSig }}) WriteVerticalSequence(x, y *int, sequence []{{ .CellType }}) {
-	if x == nil || y == nil || len(sequence) == 0 {
-		return
+//
+// The above describes the default bounds policy. With {{ .TablePkg }}BoundsWrap and
+// {{ .TablePkg }}BoundsClamp, every value is written at the cell its coordinates map to
+// and y is moved past the end of the sequence; with {{ .TablePkg }}BoundsError, nothing
+// is written unless the whole sequence fits; and, with {{ .TablePkg }}BoundsGrow, the table
+// is grown to fit the sequence unless x or y is negative, in which case nothing is
+// written. See TryWriteVerticalSequence for a variant that reports errors.
+func (t *{{ .TypeSig }}) WriteVerticalSequence(x, y *int, sequence []{{ .CellType }}) {
+	_ = t.TryWriteVerticalSequence(x, y, sequence)
+}
+
+// TryWriteVerticalSequence is the equivalent of WriteVerticalSequence but reports
+// the sequences the bounds policy rejects.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell. (Never changes)
+//   - y: The y-coordinate of the starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - error: An error if the sequence could not be written.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - *errors.ErrInvalidParameter: If x or y is nil.
+//   - *ints.ErrOutOfBounds: If the sequence does not fit in the table and the policy
+//     is either {{ .TablePkg }}BoundsError or {{ .TablePkg }}BoundsGrow, or if the table is empty and
+//     the policy is either {{ .TablePkg }}BoundsWrap or {{ .TablePkg }}BoundsClamp.
+func (t *{{ .TypeSig }}) TryWriteVerticalSequence(x, y *int, sequence []{{ .CellType }}) error {
+	if t == nil {
+		return errors.NilReceiver
+	} else if x == nil {
+		return errors.NewErrNilParameter("x")
+	} else if y == nil {
+		return errors.NewErrNilParameter("y")
+	} else if len(sequence) == 0 {
+		return nil
 	}
 
 	actualX, actualY := *x, *y
 
-	if actualX < 0 || actualX >= t.width || actualY >= t.height {
-		return
+	switch t.policy {
+	case {{ .TablePkg }}BoundsWrap, {{ .TablePkg }}BoundsClamp:
+		for i, cell := range sequence {
+			cellX, cellY, err := t.policy.Resolve(actualX, actualY+i, t.width, t.height)
+			if err != nil {
+				return err
+			}
+
+			t.table[cellY][cellX] = cell
+		}
+
+		*y = actualY + len(sequence)
+
+		return nil
+	case {{ .TablePkg }}BoundsGrow:
+		if actualX >= 0 && actualY >= 0 {
+			t.grow(actualX+1, actualY+len(sequence))
+		}
+
+		fallthrough
+	case {{ .TablePkg }}BoundsError:
+		err := {{ .TablePkg }}CheckSpan(actualX, 1, t.width)
+		if err == nil {
+			err = {{ .TablePkg }}CheckSpan(actualY, len(sequence), t.height)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if actualX < 0 || actualX >= t.width || actualY >= t.height || actualY+len(sequence) <= 0 {
+		return nil
 	}
 
 	if actualY < 0 {
 		sequence = sequence[-actualY:]
+		actualY = 0
+	}
 
-		*y = 0
-	} else if actualY+len(sequence) > t.height {
+	if actualY+len(sequence) > t.height {
 		sequence = sequence[:t.height-actualY]
 	}
 
@@ -294,7 +649,9 @@ func (t {{ .TypeSig }}) WriteVerticalSequence(x, y *int, sequence []{{ .CellType
 		t.table[actualY+i][actualX] = cell
 	}
 
-	*y += len(sequence)
+	*y = actualY + len(sequence)
+
+	return nil
 }
 
 // WriteHorizontalSequence is the equivalent of WriteVerticalSequence but for horizontal
@@ -304,30 +661,86 @@ func (t {{ .TypeSig }}) WriteVerticalSequence(x, y *int, sequence []{{ .CellType
 //
 // Parameters:
 //   - x: The x-coordinate of the starting cell.
-//   - y: The y-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell. (Never changes)
 //   - sequence: The sequence of cells to write to the table.
-func (t {{ .TypeSig }}) WriteHorizontalSequence(x, y *int, sequence []{{ .CellType }}) {
-	if x == nil || y == nil || len(sequence) == 0 {
-		return
+func (t *{{ .TypeSig }}) WriteHorizontalSequence(x, y *int, sequence []{{ .CellType }}) {
+	_ = t.TryWriteHorizontalSequence(x, y, sequence)
+}
+
+// TryWriteHorizontalSequence is the equivalent of TryWriteVerticalSequence but for
+// horizontal sequences.
+//
+// See TryWriteVerticalSequence for more information.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell. (Never changes)
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - error: An error if the sequence could not be written.
+func (t *{{ .TypeSig }}) TryWriteHorizontalSequence(x, y *int, sequence []{{ .CellType }}) error {
+	if t == nil {
+		return errors.NilReceiver
+	} else if x == nil {
+		return errors.NewErrNilParameter("x")
+	} else if y == nil {
+		return errors.NewErrNilParameter("y")
+	} else if len(sequence) == 0 {
+		return nil
 	}
 
 	actualX, actualY := *x, *y
 
-	if actualY < 0 || actualY >= t.height || actualX >= t.width {
-		return
+	switch t.policy {
+	case {{ .TablePkg }}BoundsWrap, {{ .TablePkg }}BoundsClamp:
+		for i, cell := range sequence {
+			cellX, cellY, err := t.policy.Resolve(actualX+i, actualY, t.width, t.height)
+			if err != nil {
+				return err
+			}
+
+			t.table[cellY][cellX] = cell
+		}
+
+		*x = actualX + len(sequence)
+
+		return nil
+	case {{ .TablePkg }}BoundsGrow:
+		if actualX >= 0 && actualY >= 0 {
+			t.grow(actualX+len(sequence), actualY+1)
+		}
+
+		fallthrough
+	case {{ .TablePkg }}BoundsError:
+		err := {{ .TablePkg }}CheckSpan(actualY, 1, t.height)
+		if err == nil {
+			err = {{ .TablePkg }}CheckSpan(actualX, len(sequence), t.width)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if actualY < 0 || actualY >= t.height || actualX >= t.width || actualX+len(sequence) <= 0 {
+		return nil
 	}
 
 	if actualX < 0 {
 		sequence = sequence[-actualX:]
+		actualX = 0
+	}
 
-		*x = 0
-	} else if actualX+len(sequence) > t.width {
+	if actualX+len(sequence) > t.width {
 		sequence = sequence[:t.width-actualX]
 	}
 
 	copy(t.table[actualY][actualX:], sequence)
 
 	*x = actualX + len(sequence)
+
+	return nil
 }
 
 // FullTable returns the full table as a 2D slice of elements of type {{ .CellType }}.
@@ -382,28 +795,169 @@ func (t {{ .TypeSig }}) IsYInBounds(y int) error {
 //   - x: The x-coordinate to write the table at.
 //   - y: The y-coordinate to write the table at.
 //
-// If the table is nil, x or y are nil, nothing happens.
-func (t {{ .TypeSig }}) WriteTableAt(table *{{ .TypeSig }}, x, y *int) {
-	if table == nil || x == nil || y == nil {
+// Cells of the given table that fall outside of the table are ignored. At the end of
+// the function, x and y are moved past the last column and row that could be written,
+// respectively.
+//
+// If the receiver, the table, x or y is nil, nothing happens.
+func (t *{{ .TypeSig }}) WriteTableAt(table *{{ .TypeSig }}, x, y *int) {
+	if t == nil || table == nil || x == nil || y == nil {
 		return
 	}
 
-	offsetX, offsetY := 0, 0
 	X, Y := *x, *y
 
-	for offsetY < table.height && Y+offsetY < t.height {
-		offsetX = 0
+	startX, startY := max(-X, 0), max(-Y, 0)
+	endX, endY := min(table.width, t.width-X), min(table.height, t.height-Y)
 
-		for offsetX < table.width && X+offsetX < t.width {
-			t.table[Y+offsetY][X+offsetX] = table.table[offsetY][offsetX]
-			offsetX++
+	if startX < endX {
+		for offsetY := startY; offsetY < endY; offsetY++ {
+			copy(t.table[Y+offsetY][X+startX:X+endX], table.table[offsetY][startX:endX])
 		}
+	}
 
-		offsetY++
+	endX, endY = max(endX, 0), max(endY, 0)
+
+	if endY == 0 {
+		endX = 0
 	}
 
-	*x += offsetX
-	*y += offsetY
+	*x += endX
+	*y += endY
+}
+
+// Bounds returns the rectangle covered by the table; that is, the rectangle whose
+// top-left cell is (0, 0) and whose size is the size of the table.
+//
+// Returns:
+//   - geom.Rect: The bounds of the table.
+func (t {{ .TypeSig }}) Bounds() geom.Rect {
+	return geom.Rect{
+		Width:  t.width,
+		Height: t.height,
+	}
+}
+
+// CellAtPoint is the equivalent of CellAt but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates of the cell.
+//
+// Returns:
+//   - {{ .CellType }}: The cell at the given coordinates.
+func (t {{ .TypeSig }}) CellAtPoint(p geom.Point) {{ .CellType }} {
+	return t.CellAt(p.X, p.Y)
+}
+
+// WriteAtPoint is the equivalent of WriteAt but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates of the cell.
+//   - cell: The cell to write to the table.
+func (t *{{ .TypeSig }}) WriteAtPoint(p geom.Point, cell {{ .CellType }}) {
+	t.WriteAt(p.X, p.Y, cell)
+}
+
+// WriteVerticalSequenceAt is the equivalent of WriteVerticalSequence but takes the
+// starting cell as a point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - p: The starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - geom.Point: The cell right below the last cell that was written, or p if
+//     nothing was written.
+func (t *{{ .TypeSig }}) WriteVerticalSequenceAt(p geom.Point, sequence []{{ .CellType }}) geom.Point {
+	t.WriteVerticalSequence(&p.X, &p.Y, sequence)
+
+	return p
+}
+
+// WriteHorizontalSequenceAt is the equivalent of WriteHorizontalSequence but takes the
+// starting cell as a point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - p: The starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// Returns:
+//   - geom.Point: The cell right after the last cell that was written, or p if
+//     nothing was written.
+func (t *{{ .TypeSig }}) WriteHorizontalSequenceAt(p geom.Point, sequence []{{ .CellType }}) geom.Point {
+	t.WriteHorizontalSequence(&p.X, &p.Y, sequence)
+
+	return p
+}
+
+// WriteTableAtPoint is the equivalent of WriteTableAt but takes the target cell as a
+// point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - table: The table to write to the table.
+//   - p: The cell to write the table at.
+//
+// Returns:
+//   - geom.Point: The moved point. See WriteTableAt for more information.
+func (t *{{ .TypeSig }}) WriteTableAtPoint(table *{{ .TypeSig }}, p geom.Point) geom.Point {
+	t.WriteTableAt(table, &p.X, &p.Y)
+
+	return p
+}
+
+// WriteTableIn copies the values from the given table into the given region of the
+// table. The top-left cell of the given table is written at the top-left cell of the
+// region; cells that fall outside of either the region or the table are ignored.
+//
+// Parameters:
+//   - table: The table to write to the table.
+//   - rect: The region to write the table in.
+//
+// If the receiver or the table is nil, nothing happens.
+func (t *{{ .TypeSig }}) WriteTableIn(table *{{ .TypeSig }}, rect geom.Rect) {
+	if t == nil || table == nil {
+		return
+	}
+
+	src := geom.Rect{
+		X:      rect.X,
+		Y:      rect.Y,
+		Width:  table.width,
+		Height: table.height,
+	}
+
+	clip := rect.Intersect(t.Bounds()).Intersect(src)
+	if clip.IsEmpty() {
+		return
+	}
+
+	for y := clip.Y; y < clip.Y+clip.Height; y++ {
+		copy(t.table[y][clip.X:clip.X+clip.Width], table.table[y-rect.Y][clip.X-rect.X:])
+	}
+}
+
+// FillRect sets every cell of the given region to the given value. Cells of the region
+// that fall outside of the table are ignored.
+//
+// Parameters:
+//   - rect: The region to fill.
+//   - cell: The value to write.
+//
+// If the receiver is nil, nothing happens.
+func (t *{{ .TypeSig }}) FillRect(rect geom.Rect, cell {{ .CellType }}) {
+	if t == nil {
+		return
+	}
+
+	clip := rect.Intersect(t.Bounds())
+
+	for y := clip.Y; y < clip.Y+clip.Height; y++ {
+		row := t.table[y][clip.X : clip.X+clip.Width]
+
+		for x := range row {
+			row[x] = cell
+		}
+	}
 }
 	
 // ResizeWidth resizes the table to the given width.
@@ -464,10 +1018,371 @@ func (t *{{ .TypeSig }}) ResizeHeight(new_height int) error {
 	} else if new_height < t.height {
 		t.table = t.table[:new_height]
 	} else {
-		t.table = append(t.table, make([][]{{ .CellType }}, new_height-t.height)...)
+		for i := t.height; i < new_height; i++ {
+			t.table = append(t.table, make([]{{ .CellType }}, t.width))
+		}
 	}
 
 	t.height = new_height
 
 	return nil
-}`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The table is encoded as {"width":..,"height":..,"cells":[[..]]}.
+{{- if .JSONCell }} Each cell is encoded
+// as {{ .TablePkg }}{{ .JSONEncode }} does.
+{{- end }}
+func (t {{ .TypeSig }}) MarshalJSON() ([]byte, error) {
+	cells := t.table
+	if cells == nil {
+		cells = [][]{{ .CellType }}{}
+	}
+{{ if .JSONCell }}
+	return json.Marshal({{ .TablePkg }}TableJSON[{{ .JSONCell }}]{
+		Width:  t.width,
+		Height: t.height,
+		Cells:  {{ .TablePkg }}ConvertCells(cells, {{ .TablePkg }}{{ .JSONEncode }}),
+	})
+{{- else }}
+	return json.Marshal({{ .TablePkg }}TableJSON[{{ .CellType }}]{
+		Width:  t.width,
+		Height: t.height,
+		Cells:  cells,
+	})
+{{- end }}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - error: If the data is not a valid table. See {{ .TablePkg }}TableJSON.Validate.
+func (t *{{ .TypeSig }}) UnmarshalJSON(data []byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	}
+
+	var tj {{ .TablePkg }}TableJSON[{{ if .JSONCell }}{{ .JSONCell }}{{ else }}{{ .CellType }}{{ end }}]
+
+	err := json.Unmarshal(data, &tj)
+	if err != nil {
+		return err
+	}
+
+	err = tj.Validate()
+	if err != nil {
+		return err
+	}
+{{ if .JSONCell }}
+	t.table = {{ .TablePkg }}ConvertCells(tj.Cells, {{ .TablePkg }}{{ .JSONDecode }})
+{{- else }}
+	if tj.Cells == nil {
+		tj.Cells = [][]{{ .CellType }}{}
+	}
+
+	t.table = tj.Cells
+{{- end }}
+	t.width = tj.Width
+	t.height = tj.Height
+
+	return nil
+}
+{{ if .Binary }}
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The table is encoded as a versioned header followed by its cells, row by row,
+// in little-endian order. The error is always nil.
+func (t {{ .TypeSig }}) MarshalBinary() ([]byte, error) {
+	return {{ .TablePkg }}MarshalBinaryCells(t.table, t.width, t.height)
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - error: If the data is not a binary encoded table of {{ .CellType }} cells.
+func (t *{{ .TypeSig }}) UnmarshalBinary(data []byte) error {
+	if t == nil {
+		return errors.NilReceiver
+	}
+
+	cells, width, height, err := {{ .TablePkg }}UnmarshalBinaryCells[{{ .CellType }}](data)
+	if err != nil {
+		return err
+	}
+
+	t.table = cells
+	t.width = width
+	t.height = height
+
+	return nil
+}
+{{ end }}
+{{- if .Heatmap }}
+// RenderSVG draws the table as an SVG heatmap in which each cell is a rectangle
+// colored after its value.
+{{- if or (eq .CellType "float32") (eq .CellType "float64") }} NaN cells are not drawn.{{ end }}
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the document could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (t {{ .TypeSig }}) RenderSVG(w io.Writer, opts {{ .TablePkg }}SVGOptions) error {
+	return {{ .TablePkg }}RenderHeatmap[{{ .CellType }}](w, t, opts)
+}
+{{ end }}
+{{- if .Gray }}
+{{- $float := or (eq .CellType "float32") (eq .CellType "float64") }}
+// WriteNetpbm writes the table as a Netpbm image whose pixels are the cells of the
+// table. See {{ .TablePkg }}GrayCell for how cells are mapped to gray levels.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - format: The format of the image.
+{{- if $float }}
+//   - norm: The normalization of the cells.
+{{- end }}
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil or the format is unknown.
+//   - error: Any error returned by w.
+func (t {{ .TypeSig }}) WriteNetpbm(w io.Writer, format {{ .TablePkg }}NetpbmFormat{{ if $float }}, norm {{ .TablePkg }}Normalization{{ end }}) error {
+	return {{ .TablePkg }}WriteGrayNetpbm[{{ .CellType }}](w, t, format, {{ if $float }}norm{{ else }}{{ .TablePkg }}Normalization{}{{ end }})
+}
+
+// WritePNG writes the table as a grayscale PNG image whose pixels are the cells of
+// the table. See {{ .TablePkg }}GrayCell for how cells are mapped to gray levels.
+//
+// Parameters:
+//   - w: The writer to write to.
+{{- if $float }}
+//   - norm: The normalization of the cells.
+{{- end }}
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w or by the PNG encoder.
+func (t {{ .TypeSig }}) WritePNG(w io.Writer{{ if $float }}, norm {{ .TablePkg }}Normalization{{ end }}) error {
+	return {{ .TablePkg }}WriteGrayPNG[{{ .CellType }}](w, t, {{ if $float }}norm{{ else }}{{ .TablePkg }}Normalization{}{{ end }})
+}
+
+// Read{{ .GrayName }}Netpbm reads a Netpbm image, in any of the PBM, PGM and PPM formats,
+// into a table whose cells are the pixels of the image. See {{ .TablePkg }}GrayCell for how
+// gray levels are mapped to cells.
+//
+// Parameters:
+//   - r: The reader to read from.
+{{- if $float }}
+//   - norm: The normalization of the cells.
+{{- end }}
+//
+// Returns:
+//   - *{{ .TypeSig }}: The table.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the image is malformed or any error returned by r.
+func Read{{ .GrayName }}Netpbm(r io.Reader{{ if $float }}, norm {{ .TablePkg }}Normalization{{ end }}) (*{{ .TypeSig }}, error) {
+	cells, width, height, err := {{ .TablePkg }}ReadGrayNetpbm[{{ .CellType }}](r, {{ if $float }}norm{{ else }}{{ .TablePkg }}Normalization{}{{ end }})
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{ .TypeSig }}{table: cells, width: width, height: height}, nil
+}
+
+// Read{{ .GrayName }}PNG reads a PNG image into a table whose cells are the pixels of the
+// image. See {{ .TablePkg }}GrayCell for how gray levels are mapped to cells.
+//
+// Parameters:
+//   - r: The reader to read from.
+{{- if $float }}
+//   - norm: The normalization of the cells.
+{{- end }}
+//
+// Returns:
+//   - *{{ .TypeSig }}: The table.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: Any error returned by the PNG decoder.
+func Read{{ .GrayName }}PNG(r io.Reader{{ if $float }}, norm {{ .TablePkg }}Normalization{{ end }}) (*{{ .TypeSig }}, error) {
+	cells, width, height, err := {{ .TablePkg }}ReadGrayPNG[{{ .CellType }}](r, {{ if $float }}norm{{ else }}{{ .TablePkg }}Normalization{}{{ end }})
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{ .TypeSig }}{table: cells, width: width, height: height}, nil
+}
+{{ end }}
+{{- if .ImageCell }}
+// {{ .TypeName }}FromImage creates a table whose cells are the pixels of the given image,
+// converted by {{ .TablePkg }}{{ .ImageCell }}. See {{ .TablePkg }}FromImage.
+//
+// Parameters:
+//   - img: The image.
+//
+// Returns:
+//   - *{{ .TypeSig }}: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If img is nil.
+func {{ .TypeName }}FromImage(img image.Image) (*{{ .TypeSig }}, error) {
+	t, err := {{ .TablePkg }}FromImage(img, {{ .TablePkg }}{{ .ImageCell }})
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{ .TypeSig }}{table: t.FullTable(), width: t.Width(), height: t.Height()}, nil
+}
+{{ end }}
+// New{{ .TypeName }}FromRows creates a new table whose cells are copies of the given rows.
+//
+// Parameters:
+//   - rows: The rows of the table, all of the same length.
+//
+// Returns:
+//   - *{{ .TypeSig }}: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the rows have different lengths.
+func New{{ .TypeName }}FromRows{{ .GenericsSign }}(rows [][]{{ .CellType }}) (*{{ .TypeSig }}, error) {
+	var width int
+
+	if len(rows) > 0 {
+		width = len(rows[0])
+	}
+
+	table := make([][]{{ .CellType }}, 0, len(rows))
+
+	for i, row := range rows {
+		if len(row) != width {
+			return nil, errors.NewErrInvalidParameter("rows", fmt.Errorf("row %d has %d cells but row 0 has %d", i, len(row), width))
+		}
+
+		table = append(table, append(make([]{{ .CellType }}, 0, width), row...))
+	}
+
+	return &{{ .TypeSig }}{
+		table:  table,
+		width:  width,
+		height: len(rows),
+	}, nil
+}
+
+// String implements the fmt.Stringer interface.
+//
+// It is equivalent to formatting the table with the %v verb.
+func (t {{ .TypeSig }}) String() string {
+	return fmt.Sprintf("%v", t)
+}
+
+// Format implements the fmt.Formatter interface.
+//
+// %v prints an aligned grid, %+v adds the row and column indices, and %#v prints a
+// call to New{{ .TypeName }}FromRows. See {{ .TablePkg }}FormatTable for more information.
+func (t {{ .TypeSig }}) Format(s fmt.State, verb rune) {
+	{{ .TablePkg }}FormatTable(s, verb, t.table, t.width, "{{ .PackageName }}.New{{ .TypeName }}FromRows")
+}
+
+{{- if .CSV }}
+
+// Read{{ .TypeName }}CSV reads delimited text into a new table, one record per row.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - opts: The reading options.
+//
+// Returns:
+//   - *{{ .TypeSig }}: The new table.
+//   - error: An error if the table could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - *{{ .TablePkg }}ErrCell: If a field is not a valid {{ .CellType }}, or if a record is
+//     shorter than the longest one and opts.PadRagged is false.
+//   - error: Any error returned by the underlying csv.Reader.
+func Read{{ .TypeName }}CSV(r io.Reader, opts {{ .TablePkg }}CSVOptions) (*{{ .TypeSig }}, error) {
+	cells, width, err := {{ .TablePkg }}ReadCSVFunc(r, opts, parse{{ .TypeName }}Cell)
+	if err != nil {
+		return nil, err
+	}
+
+	return &{{ .TypeSig }}{
+		table:  cells,
+		width:  width,
+		height: len(cells),
+	}, nil
+}
+
+// WriteCSV writes the table as comma-separated values, one row per record.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error if the table could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - *{{ .TablePkg }}ErrCell: If a cell could not be formatted.
+//   - error: Any error returned by w.
+func (t {{ .TypeSig }}) WriteCSV(w io.Writer) error {
+	return {{ .TablePkg }}WriteCSVFunc(w, ',', t.table, format{{ .TypeName }}Cell)
+}
+
+// WriteTSV writes the table as tab-separated values, one row per record.
+//
+// See WriteCSV for more information.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error if the table could not be written.
+func (t {{ .TypeSig }}) WriteTSV(w io.Writer) error {
+	return {{ .TablePkg }}WriteCSVFunc(w, '\t', t.table, format{{ .TypeName }}Cell)
+}
+
+// parse{{ .TypeName }}Cell parses a field of delimited text into a cell.
+//
+// Parameters:
+//   - s: The field to parse.
+//
+// Returns:
+//   - {{ .CellType }}: The cell.
+//   - error: An error if the field is not a valid {{ .CellType }}.
+func parse{{ .TypeName }}Cell(s string) ({{ .CellType }}, error) {
+{{ .ParseCell }}
+}
+
+// format{{ .TypeName }}Cell formats a cell into a field of delimited text.
+//
+// Parameters:
+//   - c: The cell to format.
+//
+// Returns:
+//   - string: The field.
+//   - error: An error if the cell could not be formatted.
+func format{{ .TypeName }}Cell(c {{ .CellType }}) (string, error) {
+{{ .FormatCell }}
+}
+{{- end }}`
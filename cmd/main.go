@@ -2,7 +2,7 @@
 //
 // To use it, run the following command:
 //
-// //go:generate go run table/cmd -name=<type_name> -type=<type> [ -g=<generics>] [ -o=<output_file> ]
+// //go:generate go run table/cmd -name=<type_name> -type=<type> [ -g=<generics>] [ -o=<output_file> ] [ -text ]
 //
 // **Flag: Name**
 //
@@ -50,6 +50,13 @@
 //		table [][]T
 //	}
 //
+// **Flag: Text**
+//
+// This optional flag tells that the cell type implements both the encoding.TextMarshaler and
+// encoding.TextUnmarshaler interfaces (the latter on its pointer). When set, the table gets the
+// Read<type_name>CSV function and the WriteCSV and WriteTSV methods. These are always generated
+// for the predeclared types (except error) and never for generic cell types.
+//
 // **Flag: Output File**
 //
 // This optional flag is used to specify the output file. If not specified, the output will be written to
@@ -0,0 +1,294 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// CellCodec converts cells of type T to and from bytes.
+type CellCodec[T any] interface {
+	// Append appends the encoding of a cell to a buffer.
+	//
+	// Parameters:
+	//   - dst: The buffer to append to.
+	//   - cell: The cell to encode.
+	//
+	// Returns:
+	//   - []byte: The extended buffer.
+	Append(dst []byte, cell T) []byte
+
+	// Read decodes a cell.
+	//
+	// Parameters:
+	//   - r: The reader to read the encoding of the cell from.
+	//
+	// Returns:
+	//   - T: The decoded cell.
+	//   - error: An error if the cell could not be decoded.
+	Read(r io.ByteReader) (T, error)
+}
+
+// CellEqualer is implemented by the codecs whose cells cannot be compared with ==;
+// for example, because two cells that are == have different encodings. The frame
+// encoders use it, when implemented, to find runs and unchanged cells.
+type CellEqualer[T any] interface {
+	// Equal checks whether two cells have the same encoding.
+	//
+	// Parameters:
+	//   - a: The first cell.
+	//   - b: The second cell.
+	//
+	// Returns:
+	//   - bool: True if the cells are equal, false otherwise.
+	Equal(a, b T) bool
+}
+
+// cellsEqual checks whether two cells are equal according to the given codec.
+//
+// Parameters:
+//   - codec: The codec of the cells.
+//   - a: The first cell.
+//   - b: The second cell.
+//
+// Returns:
+//   - bool: True if the cells are equal, false otherwise. See CellEqualer.
+func cellsEqual[T comparable](codec CellCodec[T], a, b T) bool {
+	if eq, ok := codec.(CellEqualer[T]); ok {
+		return eq.Equal(a, b)
+	}
+
+	return a == b
+}
+
+// signedCodec is the codec of the signed integer types. Cells are encoded as varints.
+type signedCodec[T ~int | ~int8 | ~int16 | ~int32 | ~int64] struct{}
+
+// Append implements the CellCodec interface.
+func (signedCodec[T]) Append(dst []byte, cell T) []byte {
+	return binary.AppendVarint(dst, int64(cell))
+}
+
+// Read implements the CellCodec interface.
+func (signedCodec[T]) Read(r io.ByteReader) (T, error) {
+	v, err := binary.ReadVarint(r)
+	if err != nil {
+		return 0, err
+	}
+
+	cell := T(v)
+	if int64(cell) != v {
+		return 0, fmt.Errorf("value %d overflows the cell type", v)
+	}
+
+	return cell, nil
+}
+
+// Signed returns the codec of a signed integer type. Cells are encoded as
+// varints, so small values take few bytes.
+//
+// Returns:
+//   - CellCodec[T]: The codec. Never returns nil.
+func Signed[T ~int | ~int8 | ~int16 | ~int32 | ~int64]() CellCodec[T] {
+	return signedCodec[T]{}
+}
+
+// unsignedCodec is the codec of the unsigned integer types. Cells are encoded as
+// unsigned varints.
+type unsignedCodec[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr] struct{}
+
+// Append implements the CellCodec interface.
+func (unsignedCodec[T]) Append(dst []byte, cell T) []byte {
+	return binary.AppendUvarint(dst, uint64(cell))
+}
+
+// Read implements the CellCodec interface.
+func (unsignedCodec[T]) Read(r io.ByteReader) (T, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+
+	cell := T(v)
+	if uint64(cell) != v {
+		return 0, fmt.Errorf("value %d overflows the cell type", v)
+	}
+
+	return cell, nil
+}
+
+// Unsigned returns the codec of an unsigned integer type. Cells are encoded as
+// unsigned varints, so small values take few bytes.
+//
+// Returns:
+//   - CellCodec[T]: The codec. Never returns nil.
+func Unsigned[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr]() CellCodec[T] {
+	return unsignedCodec[T]{}
+}
+
+// boolCodec is the codec of booleans. Cells are encoded on a single byte.
+type boolCodec struct{}
+
+// Append implements the CellCodec interface.
+func (boolCodec) Append(dst []byte, cell bool) []byte {
+	if cell {
+		return append(dst, 1)
+	}
+
+	return append(dst, 0)
+}
+
+// Read implements the CellCodec interface.
+func (boolCodec) Read(r io.ByteReader) (bool, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return false, err
+	}
+
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid boolean %d", b)
+	}
+}
+
+// readLittleEndian reads an unsigned integer of the given size in little-endian
+// order.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - size: The number of bytes to read.
+//
+// Returns:
+//   - uint64: The integer.
+//   - error: An error if the bytes could not be read.
+func readLittleEndian(r io.ByteReader, size int) (uint64, error) {
+	var bits uint64
+
+	for i := 0; i < size; i++ {
+		b, err := r.ReadByte()
+		if err == io.EOF && i > 0 {
+			return 0, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return 0, err
+		}
+
+		bits |= uint64(b) << (8 * i)
+	}
+
+	return bits, nil
+}
+
+// float32Codec is the codec of float32 values. Cells are encoded as their
+// little-endian IEEE 754 bits.
+type float32Codec struct{}
+
+// Append implements the CellCodec interface.
+func (float32Codec) Append(dst []byte, cell float32) []byte {
+	return binary.LittleEndian.AppendUint32(dst, math.Float32bits(cell))
+}
+
+// Read implements the CellCodec interface.
+func (float32Codec) Read(r io.ByteReader) (float32, error) {
+	bits, err := readLittleEndian(r, 4)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Float32frombits(uint32(bits)), nil
+}
+
+// Equal implements the CellEqualer interface. Cells are compared by their bits,
+// so that -0 differs from +0 and NaN equals itself.
+func (float32Codec) Equal(a, b float32) bool {
+	return math.Float32bits(a) == math.Float32bits(b)
+}
+
+// float64Codec is the codec of float64 values. Cells are encoded as their
+// little-endian IEEE 754 bits.
+type float64Codec struct{}
+
+// Append implements the CellCodec interface.
+func (float64Codec) Append(dst []byte, cell float64) []byte {
+	return binary.LittleEndian.AppendUint64(dst, math.Float64bits(cell))
+}
+
+// Read implements the CellCodec interface.
+func (float64Codec) Read(r io.ByteReader) (float64, error) {
+	bits, err := readLittleEndian(r, 8)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Float64frombits(bits), nil
+}
+
+// Equal implements the CellEqualer interface. Cells are compared by their bits,
+// so that -0 differs from +0 and NaN equals itself.
+func (float64Codec) Equal(a, b float64) bool {
+	return math.Float64bits(a) == math.Float64bits(b)
+}
+
+// stringCodec is the codec of strings. Cells are encoded as their length, as
+// an unsigned varint, followed by their bytes.
+type stringCodec struct{}
+
+// Append implements the CellCodec interface.
+func (stringCodec) Append(dst []byte, cell string) []byte {
+	dst = binary.AppendUvarint(dst, uint64(len(cell)))
+	return append(dst, cell...)
+}
+
+// Read implements the CellCodec interface.
+func (stringCodec) Read(r io.ByteReader) (string, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	} else if size > math.MaxInt32 {
+		return "", fmt.Errorf("string of %d bytes is too long", size)
+	}
+
+	buff := make([]byte, 0, min(size, 4096))
+
+	for i := uint64(0); i < size; i++ {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return "", io.ErrUnexpectedEOF
+		} else if err != nil {
+			return "", err
+		}
+
+		buff = append(buff, b)
+	}
+
+	return string(buff), nil
+}
+
+var (
+	// Bool is the codec of booleans.
+	Bool CellCodec[bool]
+
+	// Float32 is the codec of float32 values.
+	Float32 CellCodec[float32]
+
+	// Float64 is the codec of float64 values.
+	Float64 CellCodec[float64]
+
+	// String is the codec of strings.
+	String CellCodec[string]
+
+	// Rune is the codec of runes.
+	Rune CellCodec[rune]
+)
+
+func init() {
+	Bool = boolCodec{}
+	Float32 = float32Codec{}
+	Float64 = float64Codec{}
+	String = stringCodec{}
+	Rune = Signed[rune]()
+}
@@ -0,0 +1,369 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table"
+)
+
+// maxCells is the maximum number of cells of an encoded table. It protects the
+// decoders against corrupted sizes: since a single run can cover a whole row, the
+// size of a table cannot be checked against the size of its encoding. Rows count
+// as at least one cell so that tables without columns are capped as well. The
+// encoders check it too, so that they never write what the decoders reject.
+const maxCells = 1 << 24
+
+// checkSize checks that a table of the given size does not exceed maxCells.
+//
+// Parameters:
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - error: An error if the table is too large.
+func checkSize(width, height uint64) error {
+	if width > maxCells || height > maxCells || max(width, 1)*max(height, 1) > maxCells {
+		return fmt.Errorf("table of %dx%d cells exceeds the limit of %d cells", width, height, uint64(maxCells))
+	}
+
+	return nil
+}
+
+// AppendRLE appends the run-length encoding of a table to a buffer. Each row is
+// encoded as a sequence of runs of equal cells; runs never span two rows.
+//
+// The encoding is the width and the height, as unsigned varints, followed by
+// every run as its length, as an unsigned varint, and its cell. Cells are compared
+// with the Equal method of the codec if it implements CellEqualer, and with ==
+// otherwise.
+//
+// Parameters:
+//   - dst: The buffer to append to.
+//   - src: The table to encode.
+//   - codec: The codec of the cells.
+//
+// Returns:
+//   - []byte: The extended buffer.
+//   - error: An error if the table could not be encoded.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If src or codec is nil.
+//   - error: If the table has more than 2^24 cells.
+func AppendRLE[T comparable](dst []byte, src table.Reader[T], codec CellCodec[T]) ([]byte, error) {
+	if src == nil {
+		return dst, errors.NewErrNilParameter("src")
+	} else if codec == nil {
+		return dst, errors.NewErrNilParameter("codec")
+	}
+
+	width, height := src.Width(), src.Height()
+
+	err := checkSize(uint64(width), uint64(height))
+	if err != nil {
+		return dst, err
+	}
+
+	dst = binary.AppendUvarint(dst, uint64(width))
+	dst = binary.AppendUvarint(dst, uint64(height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; {
+			cell := src.CellAt(x, y)
+			run := runLength(src, codec, x, y, width, cell)
+
+			dst = binary.AppendUvarint(dst, uint64(run))
+			dst = codec.Append(dst, cell)
+
+			x += run
+		}
+	}
+
+	return dst, nil
+}
+
+// ReadRLE decodes a table encoded by AppendRLE.
+//
+// Parameters:
+//   - r: The reader to read the encoding from.
+//   - codec: The codec of the cells.
+//
+// Returns:
+//   - *table.Table[T]: The decoded table.
+//   - error: An error if the table could not be decoded.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r or codec is nil.
+//   - error: If the data is not a valid encoding or if the table has more than
+//     2^24 cells.
+func ReadRLE[T comparable](r io.ByteReader, codec CellCodec[T]) (*table.Table[T], error) {
+	if r == nil {
+		return nil, errors.NewErrNilParameter("r")
+	} else if codec == nil {
+		return nil, errors.NewErrNilParameter("codec")
+	}
+
+	tab, err := readSize[T](r)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := tab.Width(), tab.Height()
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; {
+			run, cell, err := readRun(r, codec, width-x)
+			if err != nil {
+				return nil, table.NewErrCell(x, y, err)
+			}
+
+			fillRun(tab, x, y, run, cell)
+			x += run
+		}
+	}
+
+	return tab, nil
+}
+
+// AppendDelta appends the encoding of a table as the difference with a previous
+// table of the same size. Cells that did not change cost almost nothing.
+//
+// Each row is encoded as a sequence of pairs made of the number of unchanged
+// cells to skip, as an unsigned varint, followed, unless the end of the row is
+// reached, by a run of equal cells encoded as in AppendRLE. Cells are compared as
+// in AppendRLE.
+//
+// Parameters:
+//   - dst: The buffer to append to.
+//   - prev: The previous table.
+//   - cur: The table to encode.
+//   - codec: The codec of the cells.
+//
+// Returns:
+//   - []byte: The extended buffer.
+//   - error: An error if the table could not be encoded.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If any of the parameters is nil or if the
+//     tables do not have the same size.
+//   - error: If the tables have more than 2^24 cells.
+func AppendDelta[T comparable](dst []byte, prev, cur table.Reader[T], codec CellCodec[T]) ([]byte, error) {
+	if prev == nil {
+		return dst, errors.NewErrNilParameter("prev")
+	} else if cur == nil {
+		return dst, errors.NewErrNilParameter("cur")
+	} else if codec == nil {
+		return dst, errors.NewErrNilParameter("codec")
+	}
+
+	width, height := cur.Width(), cur.Height()
+
+	if prev.Width() != width || prev.Height() != height {
+		return dst, errors.NewErrInvalidParameter("cur", fmt.Errorf(
+			"expected a %dx%d table, got %dx%d", prev.Width(), prev.Height(), width, height,
+		))
+	}
+
+	err := checkSize(uint64(width), uint64(height))
+	if err != nil {
+		return dst, err
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; {
+			skip := 0
+			for x+skip < width && cellsEqual(codec, cur.CellAt(x+skip, y), prev.CellAt(x+skip, y)) {
+				skip++
+			}
+
+			dst = binary.AppendUvarint(dst, uint64(skip))
+			x += skip
+
+			if x == width {
+				break
+			}
+
+			cell := cur.CellAt(x, y)
+			run := runLength(cur, codec, x, y, width, cell)
+
+			dst = binary.AppendUvarint(dst, uint64(run))
+			dst = codec.Append(dst, cell)
+
+			x += run
+		}
+	}
+
+	return dst, nil
+}
+
+// ReadDelta decodes a table encoded by AppendDelta.
+//
+// Parameters:
+//   - r: The reader to read the encoding from.
+//   - prev: The previous table. It is not modified.
+//   - codec: The codec of the cells.
+//
+// Returns:
+//   - *table.Table[T]: The decoded table.
+//   - error: An error if the table could not be decoded.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If any of the parameters is nil.
+//   - error: If the data is not a valid encoding.
+func ReadDelta[T comparable](r io.ByteReader, prev table.Reader[T], codec CellCodec[T]) (*table.Table[T], error) {
+	if r == nil {
+		return nil, errors.NewErrNilParameter("r")
+	} else if prev == nil {
+		return nil, errors.NewErrNilParameter("prev")
+	} else if codec == nil {
+		return nil, errors.NewErrNilParameter("codec")
+	}
+
+	tab, err := table.NewTable[T](prev.Width(), prev.Height())
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := tab.Width(), tab.Height()
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			tab.WriteAt(x, y, prev.CellAt(x, y))
+		}
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; {
+			skip, err := binary.ReadUvarint(r)
+			if err != nil {
+				return nil, table.NewErrCell(x, y, unexpectedEOF(err))
+			} else if skip > uint64(width-x) {
+				return nil, table.NewErrCell(x, y, fmt.Errorf("skip of %d cells overflows the row", skip))
+			}
+
+			x += int(skip)
+
+			if x == width {
+				break
+			}
+
+			run, cell, err := readRun(r, codec, width-x)
+			if err != nil {
+				return nil, table.NewErrCell(x, y, err)
+			}
+
+			fillRun(tab, x, y, run, cell)
+			x += run
+		}
+	}
+
+	return tab, nil
+}
+
+// runLength returns the number of consecutive cells, starting at the given one,
+// that are equal to the given cell.
+//
+// Parameters:
+//   - src: The table.
+//   - codec: The codec of the cells.
+//   - x: The x-coordinate of the first cell.
+//   - y: The y-coordinate of the first cell.
+//   - width: The width of the table.
+//   - cell: The cell to compare with.
+//
+// Returns:
+//   - int: The length of the run. At least 1.
+func runLength[T comparable](src table.Reader[T], codec CellCodec[T], x, y, width int, cell T) int {
+	run := 1
+	for x+run < width && cellsEqual(codec, src.CellAt(x+run, y), cell) {
+		run++
+	}
+
+	return run
+}
+
+// readSize reads the size of a table and creates an empty table of that size.
+//
+// Parameters:
+//   - r: The reader to read from.
+//
+// Returns:
+//   - *table.Table[T]: The empty table.
+//   - error: An error if the size could not be read or exceeds maxCells.
+func readSize[T any](r io.ByteReader) (*table.Table[T], error) {
+	width, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	err = checkSize(width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.NewTable[T](int(width), int(height))
+}
+
+// readRun reads a run of equal cells.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - codec: The codec of the cells.
+//   - limit: The maximum length of the run.
+//
+// Returns:
+//   - int: The length of the run.
+//   - T: The cell of the run.
+//   - error: An error if the run could not be read.
+func readRun[T any](r io.ByteReader, codec CellCodec[T], limit int) (int, T, error) {
+	run, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, *new(T), unexpectedEOF(err)
+	} else if run == 0 || run > uint64(limit) {
+		return 0, *new(T), fmt.Errorf("run of %d cells does not fit in the %d remaining cells", run, limit)
+	}
+
+	cell, err := codec.Read(r)
+	if err != nil {
+		return 0, *new(T), unexpectedEOF(err)
+	}
+
+	return int(run), cell, nil
+}
+
+// fillRun writes a run of equal cells.
+//
+// Parameters:
+//   - tab: The table to write to.
+//   - x: The x-coordinate of the first cell.
+//   - y: The y-coordinate of the first cell.
+//   - run: The length of the run.
+//   - cell: The cell of the run.
+func fillRun[T any](tab *table.Table[T], x, y, run int, cell T) {
+	for i := 0; i < run; i++ {
+		tab.WriteAt(x+i, y, cell)
+	}
+}
+
+// unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF, since it is only returned
+// in the middle of an encoding.
+//
+// Parameters:
+//   - err: The error.
+//
+// Returns:
+//   - error: The error, with io.EOF replaced.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,145 @@
+package codec_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"iter"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/codec"
+)
+
+func TestRLERoundTrip(t *testing.T) {
+	src, err := table.NewTableFromRows([][]int{{1, 1, 2}, {3, 3, 3}, {0, 1, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := codec.AppendRLE(nil, src, codec.Signed[int]())
+	if err != nil {
+		t.Fatalf("AppendRLE: %v", err)
+	}
+
+	got, err := codec.ReadRLE(bytes.NewReader(data), codec.Signed[int]())
+	if err != nil {
+		t.Fatalf("ReadRLE: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+		t.Errorf("got %v, want %v", got.FullTable(), src.FullTable())
+	}
+}
+
+func TestReadRLERejectsLargeSizes(t *testing.T) {
+	tests := map[string][2]uint64{
+		"no columns":      {0, 1<<31 - 1},
+		"no rows":         {1<<31 - 1, 0},
+		"single long row": {1<<31 - 1, 1},
+		"huge table":      {1 << 40, 1 << 40},
+		"above the cap":   {1 << 12, 1<<12 + 1},
+	}
+
+	for name, size := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := binary.AppendUvarint(nil, size[0])
+			data = binary.AppendUvarint(data, size[1])
+
+			// A single run that covers the whole first row.
+			data = binary.AppendUvarint(data, size[0])
+			data = binary.AppendVarint(data, 7)
+
+			_, err := codec.ReadRLE(bytes.NewReader(data), codec.Signed[int]())
+			if err == nil {
+				t.Errorf("ReadRLE of a %dx%d table: got nil, want an error", size[0], size[1])
+			}
+		})
+	}
+}
+
+// hugeTable is a table too large to be encoded whose cells are all zero.
+type hugeTable struct{}
+
+func (hugeTable) Width() int           { return 1 << 12 }
+func (hugeTable) Height() int          { return 1<<12 + 1 }
+func (hugeTable) CellAt(x, y int) int  { return 0 }
+func (hugeTable) Cell() iter.Seq[int]  { return func(func(int) bool) {} }
+func (hugeTable) Row() iter.Seq[[]int] { return func(func([]int) bool) {} }
+
+func TestEncodeRejectsLargeSizes(t *testing.T) {
+	_, err := codec.AppendRLE(nil, hugeTable{}, codec.Signed[int]())
+	if err == nil {
+		t.Errorf("AppendRLE: got nil, want an error")
+	}
+
+	_, err = codec.AppendDelta(nil, hugeTable{}, hugeTable{}, codec.Signed[int]())
+	if err == nil {
+		t.Errorf("AppendDelta: got nil, want an error")
+	}
+
+	enc, err := codec.NewEncoder[int](io.Discard, codec.Signed[int]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = enc.Encode(hugeTable{})
+	if err == nil {
+		t.Errorf("Encode: got nil, want an error")
+	}
+}
+
+func TestFloatCellsKeepTheirBits(t *testing.T) {
+	nan := math.NaN()
+	negZero := math.Copysign(0, -1)
+
+	prev, err := table.NewTableFromRows([][]float64{{0, 0, nan, nan, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cur, err := table.NewTableFromRows([][]float64{{negZero, 0, nan, nan, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := codec.AppendRLE(nil, cur, codec.Float64)
+	if err != nil {
+		t.Fatalf("AppendRLE: %v", err)
+	}
+
+	got, err := codec.ReadRLE(bytes.NewReader(data), codec.Float64)
+	if err != nil {
+		t.Fatalf("ReadRLE: %v", err)
+	}
+
+	if !math.Signbit(got.CellAt(0, 0)) || math.Signbit(got.CellAt(1, 0)) {
+		t.Errorf("ReadRLE: the signs of zero are lost: %v", got.FullTable())
+	}
+
+	delta, err := codec.AppendDelta(nil, prev, prev, codec.Float64)
+	if err != nil {
+		t.Fatalf("AppendDelta: %v", err)
+	}
+
+	// A single skip over the whole row.
+	if want := binary.AppendUvarint(nil, 5); !bytes.Equal(delta, want) {
+		t.Errorf("delta of unchanged NaN cells: got %v, want %v", delta, want)
+	}
+
+	delta, err = codec.AppendDelta(nil, prev, cur, codec.Float64)
+	if err != nil {
+		t.Fatalf("AppendDelta: %v", err)
+	}
+
+	got, err = codec.ReadDelta(bytes.NewReader(delta), prev, codec.Float64)
+	if err != nil {
+		t.Fatalf("ReadDelta: %v", err)
+	}
+
+	if !math.Signbit(got.CellAt(0, 0)) {
+		t.Errorf("ReadDelta: the sign of zero is lost: %v", got.FullTable())
+	}
+}
@@ -0,0 +1,283 @@
+package codec
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table"
+)
+
+// streamMagic are the first bytes of every stream of frames.
+const streamMagic = "TBLS"
+
+// streamVersion is the version of the stream format.
+const streamVersion byte = 1
+
+const (
+	// keyFrame is the tag of a frame encoded with AppendRLE.
+	keyFrame byte = iota
+
+	// deltaFrame is the tag of a frame encoded with AppendDelta against the
+	// previous frame.
+	deltaFrame
+)
+
+// Encoder writes a sequence of frames to a stream. The first frame, the frames
+// whose size differs from the previous one and, optionally, every n-th frame are
+// written as run-length encoded keyframes; the others are written as deltas
+// against the previous frame.
+type Encoder[T comparable] struct {
+	w     io.Writer
+	codec CellCodec[T]
+
+	// prev is a copy of the last frame written. Nil before the first one.
+	prev *table.Table[T]
+
+	// frames is the number of frames written since the last keyframe.
+	frames int
+
+	// buff is the buffer in which frames are encoded before being written.
+	buff []byte
+
+	// KeyframeInterval is the maximum number of frames between two keyframes.
+	// Keyframes make it possible to seek within a stream at the cost of size.
+	// 0 means that keyframes are only written when required.
+	KeyframeInterval int
+}
+
+// NewEncoder creates a new encoder that writes to the given writer.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - codec: The codec of the cells.
+//
+// Returns:
+//   - *Encoder[T]: The new encoder.
+//   - error: An error if the encoder could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or codec is nil.
+func NewEncoder[T comparable](w io.Writer, codec CellCodec[T]) (*Encoder[T], error) {
+	if w == nil {
+		return nil, errors.NewErrNilParameter("w")
+	} else if codec == nil {
+		return nil, errors.NewErrNilParameter("codec")
+	}
+
+	return &Encoder[T]{
+		w:     w,
+		codec: codec,
+	}, nil
+}
+
+// Encode writes a frame to the stream. The header of the stream is written
+// along with the first frame.
+//
+// Parameters:
+//   - frame: The frame to write.
+//
+// Returns:
+//   - error: An error if the frame could not be written.
+//
+// Errors:
+//   - errors.NilReceiver: If the encoder is nil.
+//   - *errors.ErrInvalidParameter: If the frame is nil.
+//   - error: If the frame has more than 2^24 cells or any error returned by the
+//     underlying writer.
+func (e *Encoder[T]) Encode(frame table.Reader[T]) error {
+	if e == nil {
+		return errors.NilReceiver
+	} else if frame == nil {
+		return errors.NewErrNilParameter("frame")
+	}
+
+	buff := e.buff[:0]
+
+	if e.prev == nil {
+		buff = append(buff, streamMagic...)
+		buff = append(buff, streamVersion)
+	}
+
+	is_key := e.prev == nil || e.prev.Width() != frame.Width() || e.prev.Height() != frame.Height() ||
+		(e.KeyframeInterval > 0 && e.frames >= e.KeyframeInterval)
+
+	var err error
+
+	if is_key {
+		buff = append(buff, keyFrame)
+		buff, err = AppendRLE(buff, frame, e.codec)
+	} else {
+		buff = append(buff, deltaFrame)
+		buff, err = AppendDelta(buff, e.prev, frame, e.codec)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	e.buff = buff
+
+	_, err = e.w.Write(buff)
+	if err != nil {
+		return err
+	}
+
+	prev, err := snapshot(frame)
+	if err != nil {
+		return err
+	}
+
+	e.prev = prev
+
+	if is_key {
+		e.frames = 1
+	} else {
+		e.frames++
+	}
+
+	return nil
+}
+
+// Decoder reads a sequence of frames written by an Encoder.
+type Decoder[T comparable] struct {
+	r     *bufio.Reader
+	codec CellCodec[T]
+
+	// prev is the last frame read. Nil before the first one.
+	prev *table.Table[T]
+
+	// started is true once the header of the stream has been read.
+	started bool
+}
+
+// NewDecoder creates a new decoder that reads from the given reader. The reader
+// is buffered, so it may be read past the last frame decoded.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - codec: The codec of the cells.
+//
+// Returns:
+//   - *Decoder[T]: The new decoder.
+//   - error: An error if the decoder could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r or codec is nil.
+func NewDecoder[T comparable](r io.Reader, codec CellCodec[T]) (*Decoder[T], error) {
+	if r == nil {
+		return nil, errors.NewErrNilParameter("r")
+	} else if codec == nil {
+		return nil, errors.NewErrNilParameter("codec")
+	}
+
+	return &Decoder[T]{
+		r:     bufio.NewReader(r),
+		codec: codec,
+	}, nil
+}
+
+// Decode reads the next frame of the stream.
+//
+// Returns:
+//   - *table.Table[T]: The frame. The decoder does not keep a reference to it.
+//   - error: io.EOF if there are no more frames, or an error if the frame could
+//     not be read.
+//
+// Errors:
+//   - errors.NilReceiver: If the decoder is nil.
+//   - io.EOF: If the stream ended.
+//   - error: If the stream is not valid or any error returned by the underlying
+//     reader.
+func (d *Decoder[T]) Decode() (*table.Table[T], error) {
+	if d == nil {
+		return nil, errors.NilReceiver
+	}
+
+	if !d.started {
+		err := d.readHeader()
+		if err != nil {
+			return nil, err
+		}
+
+		d.started = true
+	}
+
+	tag, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var frame *table.Table[T]
+
+	switch tag {
+	case keyFrame:
+		frame, err = ReadRLE(d.r, d.codec)
+	case deltaFrame:
+		if d.prev == nil {
+			return nil, fmt.Errorf("delta frame without a previous frame")
+		}
+
+		frame, err = ReadDelta(d.r, d.prev, d.codec)
+	default:
+		return nil, fmt.Errorf("unknown frame tag %d", tag)
+	}
+
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	d.prev, err = snapshot(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	return frame, nil
+}
+
+// readHeader reads and checks the header of the stream.
+//
+// Returns:
+//   - error: io.EOF if the stream is empty, or an error if the header is not valid.
+func (d *Decoder[T]) readHeader() error {
+	header := make([]byte, len(streamMagic)+1)
+
+	_, err := io.ReadFull(d.r, header)
+	if err != nil {
+		return err
+	}
+
+	if string(header[:len(streamMagic)]) != streamMagic {
+		return fmt.Errorf("data is not a stream of frames")
+	} else if header[len(streamMagic)] != streamVersion {
+		return fmt.Errorf("unsupported version %d", header[len(streamMagic)])
+	}
+
+	return nil
+}
+
+// snapshot returns a copy of the given table.
+//
+// Parameters:
+//   - src: The table to copy.
+//
+// Returns:
+//   - *table.Table[T]: The copy.
+//   - error: An error if the copy could not be created.
+func snapshot[T any](src table.Reader[T]) (*table.Table[T], error) {
+	width, height := src.Width(), src.Height()
+
+	cp, err := table.NewTable[T](width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cp.WriteAt(x, y, src.CellAt(x, y))
+		}
+	}
+
+	return cp, nil
+}
@@ -0,0 +1,165 @@
+package table
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// CSVOptions are the options used when reading delimited text.
+type CSVOptions struct {
+	// Comma is the field delimiter. If 0, ',' is used. Use '\t' for TSV.
+	Comma rune
+
+	// Comment, if not 0, is the rune that starts comment lines.
+	Comment rune
+
+	// PadRagged tells what happens with records that have fewer fields than the
+	// longest one. If true, they are padded with the zero value of the cells;
+	// otherwise, reading fails.
+	PadRagged bool
+
+	// TrimLeadingSpace tells whether the leading white space of fields is ignored.
+	TrimLeadingSpace bool
+}
+
+// ReadCSV reads delimited text into a new string table, one record per row.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - opts: The reading options.
+//
+// Returns:
+//   - *StringTable: The new table.
+//   - error: An error if the table could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - *ErrCell: If a record is shorter than the longest one and opts.PadRagged is false.
+//   - error: Any error returned by the underlying csv.Reader.
+func ReadCSV(r io.Reader, opts CSVOptions) (*StringTable, error) {
+	return ReadStringTableCSV(r, opts)
+}
+
+// ReadCSVFunc reads delimited text into rows of cells of the same length. It is
+// the building block of the Read<Table>CSV functions.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - opts: The reading options.
+//   - parse: The function that parses a field into a cell.
+//
+// Returns:
+//   - [][]T: The rows of cells.
+//   - int: The number of cells of each row.
+//   - error: An error if the cells could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r or parse is nil.
+//   - *ErrCell: If a field could not be parsed, or if a record is shorter than the
+//     longest one and opts.PadRagged is false.
+//   - error: Any error returned by the underlying csv.Reader.
+func ReadCSVFunc[T any](r io.Reader, opts CSVOptions, parse func(field string) (T, error)) ([][]T, int, error) {
+	if r == nil {
+		return nil, 0, errors.NewErrNilParameter("r")
+	} else if parse == nil {
+		return nil, 0, errors.NewErrNilParameter("parse")
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = opts.Comment
+	reader.TrimLeadingSpace = opts.TrimLeadingSpace
+
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var width int
+
+	for _, record := range records {
+		width = max(width, len(record))
+	}
+
+	cells := make([][]T, 0, len(records))
+
+	for y, record := range records {
+		if len(record) < width && !opts.PadRagged {
+			return nil, 0, NewErrCell(len(record), y, fmt.Errorf("expected %d fields, got %d", width, len(record)))
+		}
+
+		row := make([]T, width)
+
+		for x, field := range record {
+			cell, err := parse(field)
+			if err != nil {
+				return nil, 0, NewErrCell(x, y, err)
+			}
+
+			row[x] = cell
+		}
+
+		cells = append(cells, row)
+	}
+
+	return cells, width, nil
+}
+
+// WriteCSVFunc writes rows of cells as delimited text, one row per record. It is
+// the building block of the WriteCSV and WriteTSV methods.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - comma: The field delimiter. If 0, ',' is used.
+//   - cells: The rows of cells.
+//   - format: The function that formats a cell into a field.
+//
+// Returns:
+//   - error: An error if the cells could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or format is nil.
+//   - *ErrCell: If a cell could not be formatted.
+//   - error: Any error returned by the underlying csv.Writer.
+func WriteCSVFunc[T any](w io.Writer, comma rune, cells [][]T, format func(cell T) (string, error)) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	} else if format == nil {
+		return errors.NewErrNilParameter("format")
+	}
+
+	writer := csv.NewWriter(w)
+
+	if comma != 0 {
+		writer.Comma = comma
+	}
+
+	for y, row := range cells {
+		record := make([]string, 0, len(row))
+
+		for x, cell := range row {
+			field, err := format(cell)
+			if err != nil {
+				return NewErrCell(x, y, err)
+			}
+
+			record = append(record, field)
+		}
+
+		err := writer.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
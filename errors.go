@@ -0,0 +1,146 @@
+package table
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-commons/ints"
+)
+
+// ErrCell is an error that occurred at a specific cell of a table.
+type ErrCell struct {
+	// X is the x-coordinate, or column, of the cell.
+	X int
+
+	// Y is the y-coordinate, or row, of the cell.
+	Y int
+
+	// Reason is the reason for the error.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message:
+//   - "something went wrong at the <ordinal> column of the <ordinal> row" if Reason is nil
+//   - "<ordinal> column of the <ordinal> row is invalid: <reason>" if Reason is not nil
+//
+// Ordinals are 1-based; that is, the cell at (0, 0) is the 1st column of the 1st row.
+func (e ErrCell) Error() string {
+	var builder strings.Builder
+
+	if e.Reason == nil {
+		builder.WriteString("something went wrong at the ")
+	}
+
+	builder.WriteString(ints.GetOrdinalSuffix(e.X + 1))
+	builder.WriteString(" column of the ")
+	builder.WriteString(ints.GetOrdinalSuffix(e.Y + 1))
+	builder.WriteString(" row")
+
+	if e.Reason != nil {
+		builder.WriteString(" is invalid: ")
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap implements the errors.Unwrapper interface.
+func (e ErrCell) Unwrap() error {
+	return e.Reason
+}
+
+// ChangeReason implements the errors.Unwrapper interface.
+func (e *ErrCell) ChangeReason(reason error) bool {
+	if e == nil {
+		return false
+	}
+
+	e.Reason = reason
+
+	return true
+}
+
+// NewErrCell creates a new ErrCell error.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - reason: The reason for the error.
+//
+// Returns:
+//   - *ErrCell: A pointer to the newly created ErrCell. Never returns nil.
+func NewErrCell(x, y int, reason error) *ErrCell {
+	return &ErrCell{
+		X:      x,
+		Y:      y,
+		Reason: reason,
+	}
+}
+
+// ErrGrid is an error that occurred at a specific position of a grid literal.
+type ErrGrid struct {
+	// Line is the 1-based line of the error within the literal.
+	Line int
+
+	// Column is the 1-based column, in runes, of the error within the line.
+	Column int
+
+	// Reason is the reason for the error.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message:
+//   - "invalid grid at line <line>, column <column>" if Reason is nil
+//   - "invalid grid at line <line>, column <column>: <reason>" if Reason is not nil
+func (e ErrGrid) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString("invalid grid at line ")
+	builder.WriteString(strconv.Itoa(e.Line))
+	builder.WriteString(", column ")
+	builder.WriteString(strconv.Itoa(e.Column))
+
+	if e.Reason != nil {
+		builder.WriteString(": ")
+		builder.WriteString(e.Reason.Error())
+	}
+
+	return builder.String()
+}
+
+// Unwrap implements the errors.Unwrapper interface.
+func (e ErrGrid) Unwrap() error {
+	return e.Reason
+}
+
+// ChangeReason implements the errors.Unwrapper interface.
+func (e *ErrGrid) ChangeReason(reason error) bool {
+	if e == nil {
+		return false
+	}
+
+	e.Reason = reason
+
+	return true
+}
+
+// NewErrGrid creates a new ErrGrid error.
+//
+// Parameters:
+//   - line: The 1-based line of the error.
+//   - column: The 1-based column of the error.
+//   - reason: The reason for the error.
+//
+// Returns:
+//   - *ErrGrid: A pointer to the newly created ErrGrid. Never returns nil.
+func NewErrGrid(line, column int, reason error) *ErrGrid {
+	return &ErrGrid{
+		Line:   line,
+		Column: column,
+		Reason: reason,
+	}
+}
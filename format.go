@@ -0,0 +1,240 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FormatTable formats the cells of a table. It implements the Format method of
+// the generated tables, which implement the fmt.Formatter interface:
+//   - %v prints the cells as an aligned grid, one row per line.
+//   - %+v prints the same grid with the column indices above it and the row
+//     indices at its left.
+//   - %#v prints a Go expression that builds the table; that is, a call to the
+//     given constructor with the rows of the table. Error cells are printed as
+//     calls to errors.New with their message.
+//   - %s is the same as %v, so that it prints the same as the String method
+//     whatever the type of the cells.
+//   - Any other verb prints the grid of %v with the cells formatted by that verb;
+//     for instance, %x prints the cells in hexadecimal.
+//
+// Example:
+//
+//	fmt.Printf("%v\n", t)
+//	// [  1 2 ]
+//	// [ 30 4 ]
+//
+// The width, the precision and the other flags apply to each cell; for instance,
+// %.2f prints the cells of a float table with two decimals and %8v pads each cell
+// to eight characters. Numeric cells are right-aligned and the other cells are
+// left-aligned.
+//
+// Parameters:
+//   - s: The state of the formatter.
+//   - verb: The verb.
+//   - cells: The rows of the table.
+//   - width: The width of the table.
+//   - constructor: The qualified name of the function that builds a table from
+//     its rows, such as "table.NewIntTableFromRows".
+func FormatTable[T any](s fmt.State, verb rune, cells [][]T, width int, constructor string) {
+	if verb == 's' {
+		verb = 'v'
+	}
+
+	if verb == 'v' && s.Flag('#') {
+		io.WriteString(s, goSyntaxTable(cells, width, constructor))
+		return
+	}
+
+	indices := verb == 'v' && s.Flag('+')
+
+	format := cellFormat(s, verb)
+
+	texts := make([][]string, 0, len(cells))
+	widths := make([]int, width)
+
+	for _, row := range cells {
+		text := make([]string, 0, width)
+
+		for x := 0; x < width; x++ {
+			var cell T
+
+			if x < len(row) {
+				cell = row[x]
+			}
+
+			str := fmt.Sprintf(format, cell)
+			widths[x] = max(widths[x], StringWidth(str))
+
+			text = append(text, str)
+		}
+
+		texts = append(texts, text)
+	}
+
+	if len(texts) == 0 {
+		io.WriteString(s, "[]")
+		return
+	}
+
+	align := AlignLeft
+	if isNumericKind(reflect.TypeFor[T]().Kind()) {
+		align = AlignRight
+	}
+
+	var label_width int
+
+	if indices {
+		label_width = len(strconv.Itoa(len(texts) - 1))
+
+		for x := range widths {
+			widths[x] = max(widths[x], len(strconv.Itoa(x)))
+		}
+	}
+
+	var builder strings.Builder
+
+	if indices {
+		header := make([]string, 0, len(widths))
+
+		for x, w := range widths {
+			header = append(header, padWidth(strconv.Itoa(x), w, align))
+		}
+
+		builder.WriteString(strings.Repeat(" ", label_width+3))
+		builder.WriteString(strings.TrimRight(strings.Join(header, " "), " "))
+		builder.WriteByte('\n')
+	}
+
+	for y, text := range texts {
+		if y > 0 {
+			builder.WriteByte('\n')
+		}
+
+		if indices {
+			builder.WriteString(padWidth(strconv.Itoa(y), label_width, AlignRight))
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteString("[ ")
+
+		for x, str := range text {
+			builder.WriteString(padWidth(str, widths[x], align))
+			builder.WriteByte(' ')
+		}
+
+		builder.WriteByte(']')
+	}
+
+	io.WriteString(s, builder.String())
+}
+
+// cellFormat returns the format of each cell for the given state and verb. The
+// '+' and '#' flags of the %v verb are not passed to the cells as they select
+// the layout of the table.
+//
+// Parameters:
+//   - s: The state of the formatter.
+//   - verb: The verb.
+//
+// Returns:
+//   - string: The format of each cell.
+func cellFormat(s fmt.State, verb rune) string {
+	var builder strings.Builder
+
+	builder.WriteByte('%')
+
+	flags := "-+# 0"
+	if verb == 'v' {
+		flags = "- 0"
+	}
+
+	for _, flag := range flags {
+		if s.Flag(int(flag)) {
+			builder.WriteRune(flag)
+		}
+	}
+
+	if width, ok := s.Width(); ok {
+		builder.WriteString(strconv.Itoa(width))
+	}
+
+	if prec, ok := s.Precision(); ok {
+		builder.WriteByte('.')
+		builder.WriteString(strconv.Itoa(prec))
+	}
+
+	builder.WriteRune(verb)
+
+	return builder.String()
+}
+
+// goSyntaxTable returns the Go expression that builds a table.
+//
+// Parameters:
+//   - cells: The rows of the table.
+//   - width: The width of the table.
+//   - constructor: The qualified name of the function that builds a table from
+//     its rows.
+//
+// Returns:
+//   - string: The Go expression.
+func goSyntaxTable[T any](cells [][]T, width int, constructor string) string {
+	var builder strings.Builder
+
+	builder.WriteString(constructor)
+	builder.WriteString("([][]")
+	builder.WriteString(reflect.TypeFor[T]().String())
+	builder.WriteByte('{')
+
+	for y, row := range cells {
+		if y > 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteByte('{')
+
+		for x := 0; x < width; x++ {
+			if x > 0 {
+				builder.WriteString(", ")
+			}
+
+			var cell T
+
+			if x < len(row) {
+				cell = row[x]
+			}
+
+			switch cell := any(cell).(type) {
+			case nil:
+				builder.WriteString("nil")
+			case error:
+				// The dynamic type of an error is rarely exported; rebuild the
+				// error from its message instead.
+				fmt.Fprintf(&builder, "errors.New(%q)", cell.Error())
+			default:
+				fmt.Fprintf(&builder, "%#v", cell)
+			}
+		}
+
+		builder.WriteByte('}')
+	}
+
+	builder.WriteString("})")
+
+	return builder.String()
+}
+
+// isNumericKind checks whether the given kind is a numeric one.
+//
+// Parameters:
+//   - kind: The kind to check.
+//
+// Returns:
+//   - bool: True if the kind is numeric, false otherwise.
+func isNumericKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Complex128
+}
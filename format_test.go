@@ -0,0 +1,48 @@
+package table_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestFormat(t *testing.T) {
+	ints := must(table.NewIntTableFromRows([][]int{{1, 2}, {30, 4}}))
+	strs := must(table.NewStringTableFromRows([][]string{{"a", "bb"}}))
+	errs := must(table.NewErrorTableFromRows([][]error{{errors.New("boom"), nil}}))
+	floats := must(table.NewFloat64TableFromRows([][]float64{{1.5, 2}}))
+	empty := must(table.NewIntTable(0, 0))
+
+	tests := []struct {
+		name   string
+		format string
+		src    any
+		want   string
+	}{
+		{"grid", "%v", ints, "[  1 2 ]\n[ 30 4 ]"},
+		{"indices", "%+v", ints, "     0 1\n0 [  1 2 ]\n1 [ 30 4 ]"},
+		{"go syntax", "%#v", ints, "table.NewIntTableFromRows([][]int{{1, 2}, {30, 4}})"},
+		{"hexadecimal", "%x", ints, "[  1 2 ]\n[ 1e 4 ]"},
+		{"width", "%3v", ints, "[   1   2 ]\n[  30   4 ]"},
+		{"precision", "%.2f", floats, "[ 1.50 2.00 ]"},
+		{"String", "%s", ints, "[  1 2 ]\n[ 30 4 ]"},
+		{"left aligned indices", "%+v", strs, "    0 1\n0 [ a bb ]"},
+		{"quoted go syntax", "%#v", strs, `table.NewStringTableFromRows([][]string{{"a", "bb"}})`},
+		{"error grid", "%v", errs, "[ boom <nil> ]"},
+		{"error go syntax", "%#v", errs, `table.NewErrorTableFromRows([][]error{{errors.New("boom"), nil}})`},
+		{"empty grid", "%v", empty, "[]"},
+		{"empty indices", "%+v", empty, "[]"},
+		{"empty go syntax", "%#v", empty, "table.NewIntTableFromRows([][]int{})"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := fmt.Sprintf(tt.format, tt.src)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package table_test
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+// The fuzz targets below check the boundary handling of the shipped tables. Sizes
+// are reduced to [0, maxFuzzSize] and coordinates to [-maxFuzzSize, maxFuzzSize]
+// so that the inputs stay small.
+
+// maxFuzzSize is the maximum size of the fuzzed tables.
+const maxFuzzSize = 32
+
+// fuzzSize reduces an input to a size.
+//
+// Parameters:
+//   - n: The input.
+//
+// Returns:
+//   - int: The size, in [0, maxFuzzSize].
+func fuzzSize(n int) int {
+	n %= maxFuzzSize + 1
+
+	if n < 0 {
+		n = -n
+	}
+
+	return n
+}
+
+// fuzzCoord reduces an input to a coordinate.
+//
+// Parameters:
+//   - n: The input.
+//
+// Returns:
+//   - int: The coordinate, in [-maxFuzzSize, maxFuzzSize].
+func fuzzCoord(n int) int {
+	return n % (maxFuzzSize + 1)
+}
+
+// numbered returns a table whose cells are numbered from 1, row by row.
+//
+// Parameters:
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - *table.Uint8Table: The table.
+func numbered(width, height int) *table.Uint8Table {
+	t, _ := table.NewUint8Table(width, height)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			t.WriteAt(x, y, uint8(1+y*width+x))
+		}
+	}
+
+	return t
+}
+
+// checkConsistent fails the test if the size of a table does not match its cells.
+//
+// Parameters:
+//   - t: The test.
+//   - tbl: The table to check.
+func checkConsistent(t *testing.T, tbl *table.Uint8Table) {
+	t.Helper()
+
+	rows := tbl.FullTable()
+
+	if len(rows) != tbl.Height() {
+		t.Fatalf("table has %d rows but a height of %d", len(rows), tbl.Height())
+	}
+
+	for y, row := range rows {
+		if len(row) != tbl.Width() {
+			t.Fatalf("row %d has %d cells but the width is %d", y, len(row), tbl.Width())
+		}
+	}
+}
+
+// FuzzFixBoundaries fuzzes table.FixBoundaries: the returned elements must be
+// exactly the elements that fall within the bounds, at the returned coordinates.
+func FuzzFixBoundaries(f *testing.F) {
+	f.Add(4, 3, 2, 2, 1, 1)
+	f.Add(4, 3, 2, 2, -1, -1)
+	f.Add(4, 3, 2, 2, 5, 5)
+	f.Add(4, 3, 6, 5, -2, 2)
+	f.Add(0, 0, 1, 1, 0, 0)
+
+	f.Fuzz(func(t *testing.T, max_width, max_height, width, height, x, y int) {
+		max_width, max_height = fuzzSize(max_width), fuzzSize(max_height)
+		width, height = fuzzSize(width), fuzzSize(height)
+		x, y = fuzzCoord(x), fuzzCoord(y)
+
+		elems := numbered(width, height).FullTable()
+		orig_x, orig_y := x, y
+
+		got := table.FixBoundaries(max_width, max_height, elems, &x, &y)
+
+		if len(elems) == 0 {
+			if got != nil || x != 0 || y != 0 {
+				t.Fatalf("empty elements: got %v at (%d, %d)", got, x, y)
+			}
+
+			return
+		}
+
+		if x < 0 || x > max_width || y < 0 || y > max_height {
+			t.Fatalf("coordinates (%d, %d) are out of [0, %d]x[0, %d]", x, y, max_width, max_height)
+		}
+
+		var visible int
+
+		for r := 0; r < height; r++ {
+			for c := 0; c < width; c++ {
+				if orig_x+c >= 0 && orig_x+c < max_width && orig_y+r >= 0 && orig_y+r < max_height {
+					visible++
+				}
+			}
+		}
+
+		var count int
+
+		for i, row := range got {
+			if y+i >= max_height {
+				t.Fatalf("row %d is drawn at %d, below the height %d", i, y+i, max_height)
+			}
+
+			for j, cell := range row {
+				if x+j >= max_width {
+					t.Fatalf("cell %d of row %d is drawn at %d, past the width %d", j, i, x+j, max_width)
+				}
+
+				want := elems[y+i-orig_y][x+j-orig_x]
+				if cell != want {
+					t.Fatalf("cell at (%d, %d) is %d, want %d", x+j, y+i, cell, want)
+				}
+
+				count++
+			}
+		}
+
+		if count != visible {
+			t.Fatalf("%d cells are returned, want %d", count, visible)
+		}
+	})
+}
+
+// FuzzWriteTableAt fuzzes the WriteTableAt method: the cells of the source table
+// that fall within the destination must be copied, and only those.
+func FuzzWriteTableAt(f *testing.F) {
+	f.Add(4, 3, 2, 2, 1, 1)
+	f.Add(4, 3, 2, 2, -1, -1)
+	f.Add(4, 3, 6, 5, 3, 2)
+	f.Add(4, 3, 2, 2, 9, 9)
+
+	f.Fuzz(func(t *testing.T, width, height, src_width, src_height, x, y int) {
+		width, height = fuzzSize(width), fuzzSize(height)
+		src_width, src_height = fuzzSize(src_width), fuzzSize(src_height)
+		x, y = fuzzCoord(x), fuzzCoord(y)
+
+		dst, _ := table.NewUint8Table(width, height)
+		src := numbered(src_width, src_height)
+
+		orig_x, orig_y := x, y
+
+		dst.WriteTableAt(src, &x, &y)
+
+		checkConsistent(t, dst)
+
+		for cy := 0; cy < height; cy++ {
+			for cx := 0; cx < width; cx++ {
+				want := src.CellAt(cx-orig_x, cy-orig_y)
+
+				if got := dst.CellAt(cx, cy); got != want {
+					t.Fatalf("cell at (%d, %d) is %d, want %d", cx, cy, got, want)
+				}
+			}
+		}
+	})
+}
+
+// FuzzSequenceWriters fuzzes the WriteHorizontalSequence and WriteVerticalSequence
+// methods: the cells of the sequence that fall within the table must be written,
+// and only those.
+func FuzzSequenceWriters(f *testing.F) {
+	f.Add(3, 2, 0, -1, false, []byte("ghi"))
+	f.Add(3, 2, -5, 0, true, []byte("ab"))
+	f.Add(3, 2, 2, 1, true, []byte("abcdef"))
+	f.Add(0, 0, 0, 0, false, []byte("a"))
+
+	f.Fuzz(func(t *testing.T, width, height, x, y int, horizontal bool, data []byte) {
+		width, height = fuzzSize(width), fuzzSize(height)
+		x, y = fuzzCoord(x), fuzzCoord(y)
+
+		tbl, _ := table.NewUint8Table(width, height)
+
+		sequence := make([]uint8, 0, len(data))
+		for _, b := range data {
+			sequence = append(sequence, b|1)
+		}
+
+		orig_x, orig_y := x, y
+
+		if horizontal {
+			tbl.WriteHorizontalSequence(&x, &y, sequence)
+		} else {
+			tbl.WriteVerticalSequence(&x, &y, sequence)
+		}
+
+		checkConsistent(t, tbl)
+
+		for cy := 0; cy < height; cy++ {
+			for cx := 0; cx < width; cx++ {
+				var want uint8
+
+				idx := cx - orig_x
+				if !horizontal {
+					idx = cy - orig_y
+				}
+
+				on_line := cy == orig_y
+				if !horizontal {
+					on_line = cx == orig_x
+				}
+
+				if on_line && idx >= 0 && idx < len(sequence) {
+					want = sequence[idx]
+				}
+
+				if got := tbl.CellAt(cx, cy); got != want {
+					t.Fatalf("cell at (%d, %d) is %d, want %d", cx, cy, got, want)
+				}
+			}
+		}
+	})
+}
+
+// FuzzResize fuzzes the ResizeWidth and ResizeHeight methods: the table must stay
+// consistent, keep the cells that remain within bounds and have zero new cells.
+func FuzzResize(f *testing.F) {
+	f.Add(3, 2, 5, 4, true)
+	f.Add(3, 2, 1, 6, false)
+	f.Add(0, 0, 2, 2, true)
+	f.Add(4, 4, 0, 0, false)
+
+	f.Fuzz(func(t *testing.T, width, height, new_width, new_height int, width_first bool) {
+		width, height = fuzzSize(width), fuzzSize(height)
+		new_width, new_height = fuzzSize(new_width), fuzzSize(new_height)
+
+		tbl := numbered(width, height)
+		want := numbered(width, height)
+
+		var err error
+
+		if width_first {
+			err = tbl.ResizeWidth(new_width)
+			if err == nil {
+				err = tbl.ResizeHeight(new_height)
+			}
+		} else {
+			err = tbl.ResizeHeight(new_height)
+			if err == nil {
+				err = tbl.ResizeWidth(new_width)
+			}
+		}
+
+		if err != nil {
+			t.Fatalf("resize failed: %v", err)
+		}
+
+		checkConsistent(t, tbl)
+
+		if tbl.Width() != new_width || tbl.Height() != new_height {
+			t.Fatalf("table is %dx%d, want %dx%d", tbl.Width(), tbl.Height(), new_width, new_height)
+		}
+
+		for y := 0; y < new_height; y++ {
+			for x := 0; x < new_width; x++ {
+				if got, want := tbl.CellAt(x, y), want.CellAt(x, y); got != want {
+					t.Fatalf("cell at (%d, %d) is %d, want %d", x, y, got, want)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package geom
+
+// Point is the position of a cell; that is, a column and a row.
+type Point struct {
+	// X is the x-coordinate, or column, of the cell.
+	X int
+
+	// Y is the y-coordinate, or row, of the cell.
+	Y int
+}
+
+// Pt is a shorthand for Point{X: x, Y: y}.
+//
+// Parameters:
+//   - x: The x-coordinate of the point.
+//   - y: The y-coordinate of the point.
+//
+// Returns:
+//   - Point: The new point.
+func Pt(x, y int) Point {
+	return Point{
+		X: x,
+		Y: y,
+	}
+}
+
+// Add returns the point translated by the given offset.
+//
+// Parameters:
+//   - q: The offset to add.
+//
+// Returns:
+//   - Point: The point p+q.
+func (p Point) Add(q Point) Point {
+	return Point{
+		X: p.X + q.X,
+		Y: p.Y + q.Y,
+	}
+}
+
+// Sub returns the point translated by the opposite of the given offset.
+//
+// Parameters:
+//   - q: The offset to subtract.
+//
+// Returns:
+//   - Point: The point p-q.
+func (p Point) Sub(q Point) Point {
+	return Point{
+		X: p.X - q.X,
+		Y: p.Y - q.Y,
+	}
+}
+
+// In checks whether the point is inside the given rectangle.
+//
+// Parameters:
+//   - r: The rectangle to check against.
+//
+// Returns:
+//   - bool: True if the point is inside r, false otherwise.
+func (p Point) In(r Rect) bool {
+	return r.Contains(p)
+}
+
+// Size is the number of columns and rows of a rectangular region.
+type Size struct {
+	// Width is the number of columns.
+	Width int
+
+	// Height is the number of rows.
+	Height int
+}
+
+// Sz is a shorthand for Size{Width: width, Height: height} where negative values
+// are treated as 0.
+//
+// Parameters:
+//   - width: The number of columns.
+//   - height: The number of rows.
+//
+// Returns:
+//   - Size: The new size.
+func Sz(width, height int) Size {
+	return Size{
+		Width:  max(width, 0),
+		Height: max(height, 0),
+	}
+}
+
+// IsEmpty checks whether the size covers no cells.
+//
+// Returns:
+//   - bool: True if either dimension is not positive, false otherwise.
+func (s Size) IsEmpty() bool {
+	return s.Width <= 0 || s.Height <= 0
+}
+
+// Area returns the number of cells covered by the size.
+//
+// Returns:
+//   - int: The number of cells. Empty sizes have an area of 0.
+func (s Size) Area() int {
+	if s.IsEmpty() {
+		return 0
+	}
+
+	return s.Width * s.Height
+}
@@ -0,0 +1,277 @@
+package geom
+
+// Rect is an axis-aligned rectangle of cells. The rectangle covers the columns
+// [X, X+Width) and the rows [Y, Y+Height); a rectangle whose width or height is
+// not positive is empty.
+type Rect struct {
+	// X is the x-coordinate of the top-left cell.
+	X int
+
+	// Y is the y-coordinate of the top-left cell.
+	Y int
+
+	// Width is the number of columns of the rectangle.
+	Width int
+
+	// Height is the number of rows of the rectangle.
+	Height int
+}
+
+// NewRect creates a new rectangle. Negative sizes are treated as 0.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left cell.
+//   - y: The y-coordinate of the top-left cell.
+//   - width: The width of the rectangle.
+//   - height: The height of the rectangle.
+//
+// Returns:
+//   - Rect: The new rectangle.
+func NewRect(x, y, width, height int) Rect {
+	return Rect{
+		X:      x,
+		Y:      y,
+		Width:  max(width, 0),
+		Height: max(height, 0),
+	}
+}
+
+// RectAt creates a new rectangle with the given top-left cell and size. Negative
+// sizes are treated as 0.
+//
+// Parameters:
+//   - p: The top-left cell of the rectangle.
+//   - s: The size of the rectangle.
+//
+// Returns:
+//   - Rect: The new rectangle.
+func RectAt(p Point, s Size) Rect {
+	return NewRect(p.X, p.Y, s.Width, s.Height)
+}
+
+// RectBetween creates the smallest rectangle that covers both given cells.
+//
+// Parameters:
+//   - p: A corner of the rectangle.
+//   - q: The opposite corner of the rectangle.
+//
+// Returns:
+//   - Rect: The new rectangle. Never empty.
+func RectBetween(p, q Point) Rect {
+	return Rect{
+		X:      min(p.X, q.X),
+		Y:      min(p.Y, q.Y),
+		Width:  max(p.X, q.X) - min(p.X, q.X) + 1,
+		Height: max(p.Y, q.Y) - min(p.Y, q.Y) + 1,
+	}
+}
+
+// Min returns the top-left cell of the rectangle.
+//
+// Returns:
+//   - Point: The top-left cell.
+func (r Rect) Min() Point {
+	return Point{
+		X: r.X,
+		Y: r.Y,
+	}
+}
+
+// Max returns the cell right past the bottom-right cell of the rectangle; that is,
+// the point (X+Width, Y+Height). It is not part of the rectangle.
+//
+// Returns:
+//   - Point: The exclusive bottom-right corner.
+func (r Rect) Max() Point {
+	return Point{
+		X: r.X + r.Width,
+		Y: r.Y + r.Height,
+	}
+}
+
+// Size returns the size of the rectangle.
+//
+// Returns:
+//   - Size: The size of the rectangle.
+func (r Rect) Size() Size {
+	return Size{
+		Width:  r.Width,
+		Height: r.Height,
+	}
+}
+
+// IsEmpty checks whether the rectangle contains no cells.
+//
+// Returns:
+//   - bool: True if the rectangle is empty, false otherwise.
+func (r Rect) IsEmpty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// Contains checks whether the given cell is inside the rectangle.
+//
+// Parameters:
+//   - p: The cell to check.
+//
+// Returns:
+//   - bool: True if the cell is inside the rectangle, false otherwise.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.X && p.X < r.X+r.Width && p.Y >= r.Y && p.Y < r.Y+r.Height
+}
+
+// ContainsRect checks whether every cell of the given rectangle is inside the
+// rectangle. An empty rectangle is contained in every rectangle.
+//
+// Parameters:
+//   - other: The rectangle to check.
+//
+// Returns:
+//   - bool: True if other is inside the rectangle, false otherwise.
+func (r Rect) ContainsRect(other Rect) bool {
+	if other.IsEmpty() {
+		return true
+	}
+
+	return other.X >= r.X && other.Y >= r.Y &&
+		other.X+other.Width <= r.X+r.Width && other.Y+other.Height <= r.Y+r.Height
+}
+
+// Overlaps checks whether the two rectangles share at least one cell.
+//
+// Parameters:
+//   - other: The rectangle to check.
+//
+// Returns:
+//   - bool: True if the rectangles overlap, false otherwise.
+func (r Rect) Overlaps(other Rect) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// Intersect returns the cells shared by both rectangles.
+//
+// Parameters:
+//   - other: The rectangle to intersect with.
+//
+// Returns:
+//   - Rect: The intersection. If the rectangles do not overlap, the zero Rect is
+//     returned.
+func (r Rect) Intersect(other Rect) Rect {
+	x0, y0 := max(r.X, other.X), max(r.Y, other.Y)
+	x1, y1 := min(r.X+r.Width, other.X+other.Width), min(r.Y+r.Height, other.Y+other.Height)
+
+	if x0 >= x1 || y0 >= y1 {
+		return Rect{}
+	}
+
+	return Rect{
+		X:      x0,
+		Y:      y0,
+		Width:  x1 - x0,
+		Height: y1 - y0,
+	}
+}
+
+// Union returns the smallest rectangle that covers both rectangles. Empty rectangles
+// are ignored.
+//
+// Parameters:
+//   - other: The rectangle to unite with.
+//
+// Returns:
+//   - Rect: The union. If both rectangles are empty, the zero Rect is returned.
+func (r Rect) Union(other Rect) Rect {
+	if r.IsEmpty() {
+		if other.IsEmpty() {
+			return Rect{}
+		}
+
+		return other
+	} else if other.IsEmpty() {
+		return r
+	}
+
+	x0, y0 := min(r.X, other.X), min(r.Y, other.Y)
+	x1, y1 := max(r.X+r.Width, other.X+other.Width), max(r.Y+r.Height, other.Y+other.Height)
+
+	return Rect{
+		X:      x0,
+		Y:      y0,
+		Width:  x1 - x0,
+		Height: y1 - y0,
+	}
+}
+
+// Inset returns the rectangle shrunk by n cells on every side. A negative n grows
+// the rectangle instead.
+//
+// Parameters:
+//   - n: The number of cells to remove from each side.
+//
+// Returns:
+//   - Rect: The inset rectangle. If the rectangle is too small, an empty rectangle
+//     centered on it is returned.
+func (r Rect) Inset(n int) Rect {
+	return r.InsetXY(n, n)
+}
+
+// InsetXY is like Inset but uses different amounts horizontally and vertically.
+//
+// Parameters:
+//   - dx: The number of columns to remove from the left and the right sides.
+//   - dy: The number of rows to remove from the top and the bottom sides.
+//
+// Returns:
+//   - Rect: The inset rectangle. See Inset for more information.
+func (r Rect) InsetXY(dx, dy int) Rect {
+	if r.Width < 2*dx {
+		r.X += r.Width / 2
+		r.Width = 0
+	} else {
+		r.X += dx
+		r.Width -= 2 * dx
+	}
+
+	if r.Height < 2*dy {
+		r.Y += r.Height / 2
+		r.Height = 0
+	} else {
+		r.Y += dy
+		r.Height -= 2 * dy
+	}
+
+	return r
+}
+
+// Translate returns the rectangle moved by the given offset.
+//
+// Parameters:
+//   - offset: The offset to move the rectangle by.
+//
+// Returns:
+//   - Rect: The moved rectangle.
+func (r Rect) Translate(offset Point) Rect {
+	r.X += offset.X
+	r.Y += offset.Y
+
+	return r
+}
+
+// Clip clips the given cell to the rectangle; that is, it returns the cell of the
+// rectangle that is the closest to p.
+//
+// Parameters:
+//   - p: The cell to clip.
+//
+// Returns:
+//   - Point: The clipped cell.
+//   - bool: False if the rectangle is empty, in which case p is returned unchanged.
+func (r Rect) Clip(p Point) (Point, bool) {
+	if r.IsEmpty() {
+		return p, false
+	}
+
+	p.X = min(max(p.X, r.X), r.X+r.Width-1)
+	p.Y = min(max(p.Y, r.Y), r.Y+r.Height-1)
+
+	return p, true
+}
@@ -0,0 +1,325 @@
+package table
+
+import (
+	"io"
+	"math"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// Normalization maps the cells of a floating-point table to gray levels, and back.
+type Normalization struct {
+	// Min and Max are the values of black and white, respectively. Values outside
+	// of the range are clamped and NaN is black. If Min is not less than Max, the
+	// range of the finite cells of the table is used when writing, and [0, 1] when
+	// reading.
+	Min, Max float64
+}
+
+// GrayCell is the constraint of the cells of the tables that can be written as
+// grayscale images, and read back. The cells are mapped to gray levels as follows:
+//   - bool: true is black and false is white. Images are written with a maximum
+//     value of 1 and, when read, pixels darker than middle gray are true.
+//   - uint8 (and byte): gray levels from black (0) to white (255).
+//   - uint16: gray levels from black (0) to white (65535).
+//   - float32 and float64: values mapped to 16-bit gray levels by a Normalization.
+//
+// When read, gray levels are scaled to the range of the cells and colors are
+// converted to gray. PBM formats write the pixels darker than middle gray as black.
+type GrayCell interface {
+	bool | uint8 | uint16 | float32 | float64
+}
+
+// WriteGrayNetpbm writes a table as a Netpbm image whose pixels are the cells of the
+// table. It is used by the WriteNetpbm method of the tables.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - t: The table to write.
+//   - format: The format of the image.
+//   - norm: The normalization of the cells. Ignored unless T is a floating-point type.
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or t is nil or the format is unknown.
+//   - error: Any error returned by w.
+func WriteGrayNetpbm[T GrayCell](w io.Writer, t Reader[T], format NetpbmFormat, norm Normalization) error {
+	if t == nil {
+		return errors.NewErrNilParameter("t")
+	}
+
+	return writeNetpbm(w, format, grayRaster(t, norm))
+}
+
+// WriteGrayPNG writes a table as a grayscale PNG image whose pixels are the cells of
+// the table. Images of uint8 and bool cells have 8 bits per pixel, the others 16. It
+// is used by the WritePNG method of the tables.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - t: The table to write.
+//   - norm: The normalization of the cells. Ignored unless T is a floating-point type.
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or t is nil.
+//   - error: Any error returned by w or by the PNG encoder.
+func WriteGrayPNG[T GrayCell](w io.Writer, t Reader[T], norm Normalization) error {
+	if t == nil {
+		return errors.NewErrNilParameter("t")
+	}
+
+	return writePNG(w, grayRaster(t, norm))
+}
+
+// ReadGrayNetpbm reads a Netpbm image, in any of the PBM, PGM and PPM formats, into
+// rows of cells that are the pixels of the image. It is used by the Read<type>Netpbm
+// functions of the tables.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - norm: The normalization of the cells. Ignored unless T is a floating-point type.
+//
+// Returns:
+//   - [][]T: The rows of cells.
+//   - int: The width of the image.
+//   - int: The height of the image.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the image is malformed, if it has more than 2^24 pixels or any error
+//     returned by r.
+func ReadGrayNetpbm[T GrayCell](r io.Reader, norm Normalization) ([][]T, int, int, error) {
+	img, _, err := readNetpbm(r)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return grayCells[T](img, norm), img.width, img.height, nil
+}
+
+// ReadGrayPNG is the equivalent of ReadGrayNetpbm but for PNG images.
+//
+// Parameters:
+//   - r: The reader to read from.
+//   - norm: The normalization of the cells. Ignored unless T is a floating-point type.
+//
+// Returns:
+//   - [][]T: The rows of cells.
+//   - int: The width of the image.
+//   - int: The height of the image.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the image has more than 2^24 pixels or any error returned by the PNG
+//     decoder.
+func ReadGrayPNG[T GrayCell](r io.Reader, norm Normalization) ([][]T, int, int, error) {
+	img, err := readPNG(r)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return grayCells[T](img, norm), img.width, img.height, nil
+}
+
+// grayRaster returns the raster of the given table. See GrayCell.
+//
+// Parameters:
+//   - t: The table.
+//   - norm: The normalization of floating-point cells.
+//
+// Returns:
+//   - raster: The raster.
+func grayRaster[T GrayCell](t Reader[T], norm Normalization) raster {
+	var level func(cell T) uint16
+	var maxval int
+
+	switch fn := any(&level).(type) {
+	case *func(bool) uint16:
+		maxval = 1
+		*fn = func(cell bool) uint16 {
+			if cell {
+				return 0
+			}
+
+			return 1
+		}
+	case *func(uint8) uint16:
+		maxval = math.MaxUint8
+		*fn = func(cell uint8) uint16 {
+			return uint16(cell)
+		}
+	case *func(uint16) uint16:
+		maxval = math.MaxUint16
+		*fn = func(cell uint16) uint16 {
+			return cell
+		}
+	case *func(float32) uint16:
+		maxval = math.MaxUint16
+		*fn = floatLevel[float32](rangeOf(norm, any(t).(Reader[float32])))
+	case *func(float64) uint16:
+		maxval = math.MaxUint16
+		*fn = floatLevel[float64](rangeOf(norm, any(t).(Reader[float64])))
+	}
+
+	width, height := t.Width(), t.Height()
+	n := width * height
+
+	img := raster{
+		width:  width,
+		height: height,
+		maxval: maxval,
+		pix:    make([]uint16, 0, n),
+	}
+
+	var zero T
+
+	for row := range t.Row() {
+		if len(img.pix) == n {
+			break
+		}
+
+		for x := 0; x < width; x++ {
+			if x < len(row) {
+				img.pix = append(img.pix, level(row[x]))
+			} else {
+				img.pix = append(img.pix, level(zero))
+			}
+		}
+	}
+
+	for len(img.pix) < n {
+		img.pix = append(img.pix, level(zero))
+	}
+
+	return img
+}
+
+// grayCells returns the cells of the pixels of a raster. See GrayCell.
+//
+// Parameters:
+//   - img: The raster.
+//   - norm: The normalization of floating-point cells.
+//
+// Returns:
+//   - [][]T: The rows of cells.
+func grayCells[T GrayCell](img raster, norm Normalization) [][]T {
+	var cell func(idx int) T
+
+	switch fn := any(&cell).(type) {
+	case *func(int) bool:
+		*fn = img.isBlack
+	case *func(int) uint8:
+		*fn = func(idx int) uint8 {
+			return uint8(img.scale(idx, math.MaxUint8))
+		}
+	case *func(int) uint16:
+		*fn = func(idx int) uint16 {
+			return uint16(img.scale(idx, math.MaxUint16))
+		}
+	case *func(int) float32:
+		*fn = floatFromRaster[float32](img, norm)
+	case *func(int) float64:
+		*fn = floatFromRaster[float64](img, norm)
+	}
+
+	// The rows share one backing slice.
+	buf := make([]T, 0, img.width*img.height)
+	cells := make([][]T, 0, img.height)
+
+	for y := 0; y < img.height; y++ {
+		for x := 0; x < img.width; x++ {
+			buf = append(buf, cell(y*img.width+x))
+		}
+
+		cells = append(cells, buf[y*img.width:(y+1)*img.width:(y+1)*img.width])
+	}
+
+	return cells
+}
+
+// rangeOf returns the range of the normalization for the given table.
+//
+// Parameters:
+//   - n: The normalization.
+//   - t: The table.
+//
+// Returns:
+//   - float64: The value of black.
+//   - float64: The value of white.
+func rangeOf[T float32 | float64](n Normalization, t Reader[T]) (float64, float64) {
+	if n.Min < n.Max {
+		return n.Min, n.Max
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+
+	for row := range t.Row() {
+		for _, cell := range row {
+			f := float64(cell)
+
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				continue
+			}
+
+			lo = min(lo, f)
+			hi = max(hi, f)
+		}
+	}
+
+	if lo > hi {
+		return 0, 1
+	}
+
+	return lo, hi
+}
+
+// floatLevel returns the function that maps a floating-point cell to a 16-bit gray
+// level.
+//
+// Parameters:
+//   - lo: The value of black.
+//   - hi: The value of white.
+//
+// Returns:
+//   - func(cell T) uint16: The function.
+func floatLevel[T float32 | float64](lo, hi float64) func(cell T) uint16 {
+	return func(cell T) uint16 {
+		f := float64(cell)
+
+		switch {
+		case math.IsNaN(f) || f <= lo:
+			return 0
+		case f >= hi:
+			return math.MaxUint16
+		default:
+			return uint16(math.Round((f - lo) / (hi - lo) * math.MaxUint16))
+		}
+	}
+}
+
+// floatFromRaster returns a function that returns the floating-point cell of a
+// pixel of a raster.
+//
+// Parameters:
+//   - img: The raster.
+//   - norm: The normalization of the cells.
+//
+// Returns:
+//   - func(idx int) T: The function.
+func floatFromRaster[T float32 | float64](img raster, norm Normalization) func(idx int) T {
+	lo, hi := norm.Min, norm.Max
+	if lo >= hi {
+		lo, hi = 0, 1
+	}
+
+	return func(idx int) T {
+		return T(lo + float64(img.pix[idx])/float64(img.maxval)*(hi-lo))
+	}
+}
@@ -0,0 +1,357 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// NullGridRune is the rune that stands for the null rune in the grid literals of
+// rune tables. It is the symbol for null, '␀'.
+const NullGridRune rune = '\u2400'
+
+// ParseGrid parses a grid literal into a table; that is, a multi-line string in
+// which each line is a row and each rune is a cell. It is meant to be used with
+// raw string literals:
+//
+//	t, err := ParseGrid(`
+//		#..
+//		.#.
+//	`, parse)
+//
+// Blank lines at the start and at the end of the literal are ignored, and so is
+// the indentation common to all the other lines; that is, the leading whitespace
+// runes they all share. Then, all the rows must have the same number of runes.
+//
+// Parameters:
+//   - s: The grid literal.
+//   - parse: The function that parses a rune into a cell.
+//
+// Returns:
+//   - *Table[T]: The table.
+//   - error: An error if the literal could not be parsed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If parse is nil.
+//   - *ErrGrid: If the rows have different lengths or a rune could not be parsed.
+//     Lines and columns are those of the literal, indentation included.
+func ParseGrid[T any](s string, parse func(r rune) (T, error)) (*Table[T], error) {
+	if parse == nil {
+		return nil, errors.NewErrNilParameter("parse")
+	}
+
+	cells, err := parseGrid(s, parse)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := NewTable[T](gridSize(cells))
+	if err != nil {
+		return nil, err
+	}
+
+	copyGrid(t.table, cells)
+
+	return t, nil
+}
+
+// ParseRuneGrid parses a grid literal, as ParseGrid does, into a rune table whose
+// cells are the runes of the literal, except for NullGridRune which is parsed as the
+// null rune.
+//
+// Parameters:
+//   - s: The grid literal.
+//
+// Returns:
+//   - *RuneTable: The table.
+//   - error: An error if the literal could not be parsed.
+//
+// Errors:
+//   - *ErrGrid: If the rows have different lengths.
+func ParseRuneGrid(s string) (*RuneTable, error) {
+	cells, err := parseGrid(s, func(r rune) (rune, error) {
+		if r == NullGridRune {
+			return 0, nil
+		}
+
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := NewRuneTable(gridSize(cells))
+	if err != nil {
+		return nil, err
+	}
+
+	copyGrid(t.table, cells)
+
+	return t, nil
+}
+
+// ParseBoolGrid parses a grid literal, as ParseGrid does, into a bool table in
+// which '#' is true and '.' is false.
+//
+// Parameters:
+//   - s: The grid literal.
+//
+// Returns:
+//   - *BoolTable: The table.
+//   - error: An error if the literal could not be parsed.
+//
+// Errors:
+//   - *ErrGrid: If the rows have different lengths or a rune is neither '#' nor '.'.
+func ParseBoolGrid(s string) (*BoolTable, error) {
+	cells, err := parseGrid(s, parseBoolRune)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := NewBoolTable(gridSize(cells))
+	if err != nil {
+		return nil, err
+	}
+
+	copyGrid(t.table, cells)
+
+	return t, nil
+}
+
+// FormatGrid formats a table as a grid literal; that is, one line per row and one
+// rune per cell. Lines are separated by newlines, without a trailing one.
+//
+// Since ParseGrid ignores blank lines and indentation, it parses the literal back
+// only if no row and no leading column of the literal is made of whitespace alone,
+// and if no cell is formatted as a line break.
+//
+// Parameters:
+//   - t: The table to format.
+//   - format: The function that formats a cell into a rune.
+//
+// Returns:
+//   - string: The grid literal. Empty if t or format is nil.
+func FormatGrid[T any](t Reader[T], format func(cell T) rune) string {
+	if t == nil || format == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	width, height := t.Width(), t.Height()
+
+	for y := 0; y < height; y++ {
+		if y > 0 {
+			builder.WriteByte('\n')
+		}
+
+		for x := 0; x < width; x++ {
+			builder.WriteRune(format(t.CellAt(x, y)))
+		}
+	}
+
+	return builder.String()
+}
+
+// FormatRuneGrid formats a rune table as a grid literal. Null runes are formatted
+// as NullGridRune so that ParseRuneGrid parses them back; whitespace cells are
+// subject to the same restrictions as in FormatGrid.
+//
+// Parameters:
+//   - t: The table to format.
+//
+// Returns:
+//   - string: The grid literal. Empty if t is nil.
+func FormatRuneGrid(t *RuneTable) string {
+	if t == nil {
+		return ""
+	}
+
+	return FormatGrid(t, func(cell rune) rune {
+		if cell == 0 {
+			return NullGridRune
+		}
+
+		return cell
+	})
+}
+
+// FormatBoolGrid formats a bool table as a grid literal that ParseBoolGrid parses
+// back; that is, with '#' for true and '.' for false. Since neither is whitespace,
+// every bool table survives the round trip.
+//
+// Parameters:
+//   - t: The table to format.
+//
+// Returns:
+//   - string: The grid literal. Empty if t is nil.
+func FormatBoolGrid(t *BoolTable) string {
+	if t == nil {
+		return ""
+	}
+
+	return FormatGrid(t, func(cell bool) rune {
+		if cell {
+			return '#'
+		}
+
+		return '.'
+	})
+}
+
+// parseBoolRune parses a rune of a bool grid literal.
+//
+// Parameters:
+//   - r: The rune to parse.
+//
+// Returns:
+//   - bool: True for '#', false for '.'.
+//   - error: An error if the rune is neither '#' nor '.'.
+func parseBoolRune(r rune) (bool, error) {
+	switch r {
+	case '#':
+		return true, nil
+	case '.':
+		return false, nil
+	default:
+		return false, fmt.Errorf("expected '#' or '.', got %q", r)
+	}
+}
+
+// parseGrid parses a grid literal into rows of cells.
+//
+// Parameters:
+//   - s: The grid literal.
+//   - parse: The function that parses a rune into a cell.
+//
+// Returns:
+//   - [][]T: The rows of cells, all of the same length.
+//   - error: An error if the literal could not be parsed.
+func parseGrid[T any](s string, parse func(r rune) (T, error)) ([][]T, error) {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+
+	isBlank := func(line string) bool {
+		return strings.TrimSpace(line) == ""
+	}
+
+	first, last := 0, len(lines)
+
+	for first < last && isBlank(lines[first]) {
+		first++
+	}
+
+	for last > first && isBlank(lines[last-1]) {
+		last--
+	}
+
+	lines = lines[first:last]
+
+	// The indentation is compared rune by rune so that a tab never matches a
+	// space and multibyte whitespace is never split.
+	var indent string
+
+	found := false
+
+	for _, line := range lines {
+		if isBlank(line) {
+			continue
+		}
+
+		lead := strings.TrimSuffix(line, strings.TrimLeftFunc(line, unicode.IsSpace))
+
+		if !found {
+			indent, found = lead, true
+		} else {
+			indent = commonPrefix(indent, lead)
+		}
+	}
+
+	cells := make([][]T, 0, len(lines))
+	width := -1
+
+	for i, line := range lines {
+		line_no := first + i + 1
+
+		// Blank lines may be shorter than the indentation.
+		prefix := commonPrefix(line, indent)
+
+		col := utf8.RuneCountInString(prefix)
+		row := make([]T, 0, len(line)-len(prefix))
+
+		for _, r := range line[len(prefix):] {
+			col++
+
+			cell, err := parse(r)
+			if err != nil {
+				return nil, NewErrGrid(line_no, col, err)
+			}
+
+			row = append(row, cell)
+		}
+
+		if width == -1 {
+			width = len(row)
+		} else if len(row) != width {
+			column := min(len(row), width) + utf8.RuneCountInString(prefix) + 1
+
+			return nil, NewErrGrid(line_no, column, fmt.Errorf("row has %d cells but the first row has %d", len(row), width))
+		}
+
+		cells = append(cells, row)
+	}
+
+	return cells, nil
+}
+
+// commonPrefix returns the longest prefix of whole runes shared by two strings.
+//
+// Parameters:
+//   - a: The first string.
+//   - b: The second string.
+//
+// Returns:
+//   - string: The common prefix, a prefix of a.
+func commonPrefix(a, b string) string {
+	var n int
+
+	for n < len(a) && n < len(b) {
+		_, size := utf8.DecodeRuneInString(a[n:])
+		if n+size > len(b) || a[n:n+size] != b[n:n+size] {
+			break
+		}
+
+		n += size
+	}
+
+	return a[:n]
+}
+
+// gridSize returns the size of the given rows of cells.
+//
+// Parameters:
+//   - cells: The rows of cells, all of the same length.
+//
+// Returns:
+//   - int: The width of the grid.
+//   - int: The height of the grid.
+func gridSize[T any](cells [][]T) (int, int) {
+	if len(cells) == 0 {
+		return 0, 0
+	}
+
+	return len(cells[0]), len(cells)
+}
+
+// copyGrid copies the given rows of cells into a table of the same size.
+//
+// Parameters:
+//   - dst: The cells of the table.
+//   - cells: The rows of cells.
+func copyGrid[T any](dst, cells [][]T) {
+	for y, row := range cells {
+		copy(dst[y], row)
+	}
+}
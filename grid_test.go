@@ -0,0 +1,79 @@
+package table_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestRuneGridRoundTrip(t *testing.T) {
+	src := must(table.NewRuneTableFromRows([][]rune{{0, 'a', 0}, {'b', ' ', 'c'}, {0, 0, 0}}))
+
+	s := table.FormatRuneGrid(src)
+
+	got, err := table.ParseRuneGrid(s)
+	if err != nil {
+		t.Fatalf("ParseRuneGrid(%q): %v", s, err)
+	}
+
+	if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+		t.Errorf("got %q, want %q (formatted as %q)", got.FullTable(), src.FullTable(), s)
+	}
+}
+
+func TestBoolGridRoundTrip(t *testing.T) {
+	src := must(table.NewBoolTableFromRows([][]bool{{false, false}, {true, false}, {false, false}}))
+
+	s := table.FormatBoolGrid(src)
+
+	got, err := table.ParseBoolGrid(s)
+	if err != nil {
+		t.Fatalf("ParseBoolGrid(%q): %v", s, err)
+	}
+
+	if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+		t.Errorf("got %v, want %v (formatted as %q)", got.FullTable(), src.FullTable(), s)
+	}
+}
+
+func TestParseGridIndentation(t *testing.T) {
+	got, err := table.ParseRuneGrid(`
+		#.
+		.#
+	`)
+	if err != nil {
+		t.Fatalf("ParseRuneGrid: %v", err)
+	}
+
+	want := [][]rune{{'#', '.'}, {'.', '#'}}
+	if !reflect.DeepEqual(got.FullTable(), want) {
+		t.Errorf("got %q, want %q", got.FullTable(), want)
+	}
+}
+
+func TestParseGridMixedIndentation(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want [][]rune
+	}{
+		{"tab and spaces", "\t ab\n\t\tcd", [][]rune{{' ', 'a', 'b'}, {'\t', 'c', 'd'}}},
+		{"spaces and tab", "  ab\n\t cd", [][]rune{{' ', ' ', 'a', 'b'}, {'\t', ' ', 'c', 'd'}}},
+		{"multibyte space", "　ab\n　cd", [][]rune{{'a', 'b'}, {'c', 'd'}}},
+		{"multibyte and ascii", "　ab\n  c", [][]rune{{'　', 'a', 'b'}, {' ', ' ', 'c'}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := table.ParseRuneGrid(tt.s)
+			if err != nil {
+				t.Fatalf("ParseRuneGrid(%q): %v", tt.s, err)
+			}
+
+			if !reflect.DeepEqual(got.FullTable(), tt.want) {
+				t.Errorf("got %q, want %q", got.FullTable(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package table
+
+import (
+	"fmt"
+	"iter"
+	"math"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table/geom"
+)
+
+// GrowableTable is a table without fixed bounds: writing past its right or bottom
+// edge grows it and writing past its left or top edge moves its origin.
+//
+// At, Set and the sequence writers address cells by the coordinates they were
+// written at, which may be negative. As a ReadWriter, however, the table is the
+// occupied extent: CellAt and WriteAt, like Row and Cell, take coordinates relative
+// to Origin. The occupied extent is limited to 2^24 cells.
+//
+// The zero value is an empty table ready to use.
+type GrowableTable[T any] struct {
+	// table is the storage of the cells. It covers area, which may be larger than
+	// extent so that growing is amortized.
+	table *Table[T]
+
+	// area is the region, in user coordinates, covered by table.
+	area geom.Rect
+
+	// extent is the region, in user coordinates, of the cells that were written.
+	extent geom.Rect
+}
+
+// _ asserts that every instance of GrowableTable implements the ReadWriter interface.
+func _[T any]() {
+	var _ ReadWriter[T] = (*GrowableTable[T])(nil)
+}
+
+// growableMaxCells is the maximum number of cells of the occupied extent of a
+// GrowableTable.
+const growableMaxCells = 1 << 24
+
+// NewGrowableTable creates a new empty growable table.
+//
+// Returns:
+//   - *GrowableTable[T]: The new table. Never returns nil.
+func NewGrowableTable[T any]() *GrowableTable[T] {
+	return &GrowableTable[T]{}
+}
+
+// Bounds returns the occupied extent of the table; that is, the smallest rectangle
+// that covers every cell written so far.
+//
+// Returns:
+//   - geom.Rect: The occupied extent. The zero Rect if nothing was written.
+func (g GrowableTable[T]) Bounds() geom.Rect {
+	return g.extent
+}
+
+// Origin returns the coordinates of the top-left cell of the occupied extent.
+//
+// Returns:
+//   - geom.Point: The origin of the table.
+func (g GrowableTable[T]) Origin() geom.Point {
+	return g.extent.Min()
+}
+
+// Width returns the width of the occupied extent.
+//
+// Returns:
+//   - int: The width of the table. Never negative.
+func (g GrowableTable[T]) Width() int {
+	return g.extent.Width
+}
+
+// Height returns the height of the occupied extent.
+//
+// Returns:
+//   - int: The height of the table. Never negative.
+func (g GrowableTable[T]) Height() int {
+	return g.extent.Height
+}
+
+// At returns the cell written at the given coordinates. Coordinates outside of the
+// occupied extent return the zero value of T.
+//
+// Parameters:
+//   - x: The x-coordinate the cell was written at.
+//   - y: The y-coordinate the cell was written at.
+//
+// Returns:
+//   - T: The cell at the given coordinates.
+func (g GrowableTable[T]) At(x, y int) T {
+	if !g.extent.Contains(geom.Pt(x, y)) {
+		return *new(T)
+	}
+
+	return g.table.CellAt(x-g.area.X, y-g.area.Y)
+}
+
+// AtPoint is the equivalent of At but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates the cell was written at.
+//
+// Returns:
+//   - T: The cell at the given coordinates.
+func (g GrowableTable[T]) AtPoint(p geom.Point) T {
+	return g.At(p.X, p.Y)
+}
+
+// CellAt returns the cell at the given coordinates relative to Origin; that is,
+// CellAt(0, 0) is the top-left cell of the occupied extent. Coordinates outside of
+// the occupied extent return the zero value of T.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell, relative to Origin.
+//   - y: The y-coordinate of the cell, relative to Origin.
+//
+// Returns:
+//   - T: The cell at the given coordinates.
+func (g GrowableTable[T]) CellAt(x, y int) T {
+	return g.At(g.extent.X+x, g.extent.Y+y)
+}
+
+// CellAtPoint is the equivalent of CellAt but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates of the cell, relative to Origin.
+//
+// Returns:
+//   - T: The cell at the given coordinates.
+func (g GrowableTable[T]) CellAtPoint(p geom.Point) T {
+	return g.CellAt(p.X, p.Y)
+}
+
+// Reserve grows the table so that it covers the given region. The occupied extent
+// is grown as well; that is, the cells of the region are considered written.
+//
+// Parameters:
+//   - rect: The region to cover. Empty regions are ignored.
+//
+// Returns:
+//   - error: An error if the table could not be grown.
+//
+// Errors:
+//   - errors.NilReceiver: If the receiver is nil.
+//   - *errors.ErrInvalidParameter: If the occupied extent would have more than
+//     2^24 cells.
+func (g *GrowableTable[T]) Reserve(rect geom.Rect) error {
+	if g == nil {
+		return errors.NilReceiver
+	} else if rect.IsEmpty() {
+		return nil
+	}
+
+	extent, err := g.grownExtent(rect)
+	if err != nil {
+		return errors.NewErrInvalidParameter("rect", err)
+	}
+
+	if g.table != nil && g.area.ContainsRect(rect) {
+		g.extent = extent
+		return nil
+	}
+
+	area := g.grownArea(rect, extent)
+
+	table, err := NewTable[T](area.Width, area.Height)
+	if err != nil {
+		return err
+	}
+
+	if g.table != nil {
+		x, y := g.area.X-area.X, g.area.Y-area.Y
+		table.WriteTableAt(g.table, &x, &y)
+	}
+
+	g.table = table
+	g.area = area
+	g.extent = extent
+
+	return nil
+}
+
+// grownExtent returns the occupied extent once the given region is written.
+//
+// Parameters:
+//   - rect: The region. Must not be empty.
+//
+// Returns:
+//   - geom.Rect: The occupied extent.
+//   - error: An error if the extent would have more than growableMaxCells cells.
+func (g GrowableTable[T]) grownExtent(rect geom.Rect) (geom.Rect, error) {
+	if rect.X > math.MaxInt-rect.Width || rect.Y > math.MaxInt-rect.Height {
+		return geom.Rect{}, fmt.Errorf("region at (%d, %d) overflows the coordinates", rect.X, rect.Y)
+	}
+
+	extent := g.extent
+	if extent.IsEmpty() {
+		extent = rect
+	}
+
+	x0, y0 := min(extent.X, rect.X), min(extent.Y, rect.Y)
+	x1, y1 := max(extent.X+extent.Width, rect.X+rect.Width), max(extent.Y+extent.Height, rect.Y+rect.Height)
+
+	// The differences are computed on unsigned integers since they may not fit in
+	// an int.
+	width, height := uint64(x1)-uint64(x0), uint64(y1)-uint64(y0)
+
+	if width > growableMaxCells || height > growableMaxCells || width*height > growableMaxCells {
+		return geom.Rect{}, fmt.Errorf("table of %dx%d cells exceeds the limit of %d cells", width, height, growableMaxCells)
+	}
+
+	return geom.NewRect(x0, y0, int(width), int(height)), nil
+}
+
+// grownArea returns the region the storage must cover so that the given region
+// fits. The storage grows by at least half of its current size on every side that
+// needs to grow so that writing cells one by one takes amortized constant time,
+// unless that would exceed growableMaxCells.
+//
+// Parameters:
+//   - rect: The region to cover.
+//   - extent: The occupied extent once rect is written.
+//
+// Returns:
+//   - geom.Rect: The region to cover.
+func (g GrowableTable[T]) grownArea(rect, extent geom.Rect) geom.Rect {
+	area := g.area.Union(rect)
+	if area.Width*area.Height > growableMaxCells {
+		return extent
+	}
+
+	slackX, slackY := g.area.Width/2, g.area.Height/2
+
+	if area.X < g.area.X && area.X >= math.MinInt+slackX {
+		area.X -= slackX
+		area.Width += slackX
+	}
+
+	if area.X+area.Width > g.area.X+g.area.Width && area.X+area.Width <= math.MaxInt-slackX {
+		area.Width += slackX
+	}
+
+	if area.Y < g.area.Y && area.Y >= math.MinInt+slackY {
+		area.Y -= slackY
+		area.Height += slackY
+	}
+
+	if area.Y+area.Height > g.area.Y+g.area.Height && area.Y+area.Height <= math.MaxInt-slackY {
+		area.Height += slackY
+	}
+
+	if area.Width*area.Height > growableMaxCells {
+		return g.area.Union(rect)
+	}
+
+	return area
+}
+
+// TrySet writes a cell at the given coordinates, growing the table if needed.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write.
+//
+// Returns:
+//   - error: An error if the table could not be grown. See Reserve.
+func (g *GrowableTable[T]) TrySet(x, y int, cell T) error {
+	err := g.Reserve(geom.NewRect(x, y, 1, 1))
+	if err != nil {
+		return err
+	}
+
+	g.table.WriteAt(x-g.area.X, y-g.area.Y, cell)
+
+	return nil
+}
+
+// Set is the equivalent of TrySet but ignores the writes that would grow the
+// occupied extent past 2^24 cells.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write.
+//
+// If the receiver is nil, nothing happens.
+func (g *GrowableTable[T]) Set(x, y int, cell T) {
+	_ = g.TrySet(x, y, cell)
+}
+
+// WriteAt implements the Writer interface. Unlike Set, the coordinates are relative
+// to Origin, like those of CellAt; writing outside of the occupied extent grows it.
+// Writes that would grow the occupied extent past 2^24 cells are ignored.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell, relative to Origin.
+//   - y: The y-coordinate of the cell, relative to Origin.
+//   - cell: The cell to write.
+//
+// If the receiver is nil, nothing happens.
+func (g *GrowableTable[T]) WriteAt(x, y int, cell T) {
+	if g == nil {
+		return
+	}
+
+	g.Set(g.extent.X+x, g.extent.Y+y, cell)
+}
+
+// SetPoint is the equivalent of Set but takes the coordinates as a point.
+//
+// Parameters:
+//   - p: The coordinates of the cell.
+//   - cell: The cell to write.
+func (g *GrowableTable[T]) SetPoint(p geom.Point, cell T) {
+	g.Set(p.X, p.Y, cell)
+}
+
+// WriteVerticalSequence writes the given values downwards starting at the given
+// coordinates, growing the table so that the whole sequence fits. As with Set, the
+// coordinates are not relative to Origin.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell. (Never changes)
+//   - y: The y-coordinate of the starting cell.
+//   - sequence: The sequence of cells to write.
+//
+// At the end of the function, y points to the cell right below the last cell of the
+// sequence. If the receiver, x or y is nil, or if the occupied extent would grow past
+// 2^24 cells, nothing happens.
+func (g *GrowableTable[T]) WriteVerticalSequence(x, y *int, sequence []T) {
+	if g == nil || x == nil || y == nil || len(sequence) == 0 {
+		return
+	}
+
+	err := g.Reserve(geom.NewRect(*x, *y, 1, len(sequence)))
+	if err != nil {
+		return
+	}
+
+	localX, localY := *x-g.area.X, *y-g.area.Y
+	g.table.WriteVerticalSequence(&localX, &localY, sequence)
+
+	*y += len(sequence)
+}
+
+// WriteHorizontalSequence is the equivalent of WriteVerticalSequence but for
+// horizontal sequences.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell. (Never changes)
+//   - sequence: The sequence of cells to write.
+//
+// At the end of the function, x points to the cell right after the last cell of the
+// sequence. If the receiver, x or y is nil, or if the occupied extent would grow past
+// 2^24 cells, nothing happens.
+func (g *GrowableTable[T]) WriteHorizontalSequence(x, y *int, sequence []T) {
+	if g == nil || x == nil || y == nil || len(sequence) == 0 {
+		return
+	}
+
+	err := g.Reserve(geom.NewRect(*x, *y, len(sequence), 1))
+	if err != nil {
+		return
+	}
+
+	localX, localY := *x-g.area.X, *y-g.area.Y
+	g.table.WriteHorizontalSequence(&localX, &localY, sequence)
+
+	*x += len(sequence)
+}
+
+// WriteVerticalSequenceAt is the equivalent of WriteVerticalSequence but takes the
+// starting cell as a point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - p: The starting cell.
+//   - sequence: The sequence of cells to write.
+//
+// Returns:
+//   - geom.Point: The cell right below the last cell of the sequence.
+func (g *GrowableTable[T]) WriteVerticalSequenceAt(p geom.Point, sequence []T) geom.Point {
+	g.WriteVerticalSequence(&p.X, &p.Y, sequence)
+
+	return p
+}
+
+// WriteHorizontalSequenceAt is the equivalent of WriteHorizontalSequence but takes
+// the starting cell as a point and returns the moved point instead of modifying it.
+//
+// Parameters:
+//   - p: The starting cell.
+//   - sequence: The sequence of cells to write.
+//
+// Returns:
+//   - geom.Point: The cell right after the last cell of the sequence.
+func (g *GrowableTable[T]) WriteHorizontalSequenceAt(p geom.Point, sequence []T) geom.Point {
+	g.WriteHorizontalSequence(&p.X, &p.Y, sequence)
+
+	return p
+}
+
+// Row returns an iterator over the rows of the occupied extent, from top to bottom.
+// The yielded slices share their memory with the table and are only valid until the
+// next write.
+//
+// Returns:
+//   - iter.Seq[[]T]: The iterator. Never returns nil.
+func (g GrowableTable[T]) Row() iter.Seq[[]T] {
+	fn := func(yield func([]T) bool) {
+		if g.extent.IsEmpty() {
+			return
+		}
+
+		rows := g.table.FullTable()
+		x := g.extent.X - g.area.X
+
+		for y := g.extent.Y - g.area.Y; y < g.extent.Y-g.area.Y+g.extent.Height; y++ {
+			if !yield(rows[y][x : x+g.extent.Width]) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Cell returns an iterator over the cells of the occupied extent, row by row.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (g GrowableTable[T]) Cell() iter.Seq[T] {
+	fn := func(yield func(T) bool) {
+		for row := range g.Row() {
+			for _, cell := range row {
+				if !yield(cell) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Table returns a copy of the occupied extent as a table whose top-left cell is the
+// origin of the growable table.
+//
+// Returns:
+//   - *Table[T]: The copy. Never returns nil.
+func (g GrowableTable[T]) Table() *Table[T] {
+	table, _ := NewTable[T](g.extent.Width, g.extent.Height)
+
+	var y int
+
+	for row := range g.Row() {
+		var x int
+
+		table.WriteHorizontalSequence(&x, &y, row)
+		y++
+	}
+
+	return table
+}
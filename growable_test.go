@@ -0,0 +1,144 @@
+package table_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+func TestGrowableTable(t *testing.T) {
+	g := table.NewGrowableTable[int]()
+
+	g.Set(2, 1, 1)
+	g.Set(-1, -2, 2)
+
+	x, y := 0, 3
+	g.WriteHorizontalSequence(&x, &y, []int{3, 4})
+
+	if got, want := g.Bounds(), geom.NewRect(-1, -2, 4, 6); got != want {
+		t.Fatalf("Bounds is %+v, want %+v", got, want)
+	}
+
+	if got, want := g.Origin(), geom.Pt(-1, -2); got != want {
+		t.Errorf("Origin is %+v, want %+v", got, want)
+	}
+
+	// At takes the coordinates the cells were written at.
+	if got := g.At(-1, -2); got != 2 {
+		t.Errorf("At(-1, -2) is %d, want 2", got)
+	}
+
+	if got := g.AtPoint(geom.Pt(2, 1)); got != 1 {
+		t.Errorf("AtPoint(2, 1) is %d, want 1", got)
+	}
+
+	// CellAt is relative to the origin, like Row.
+	if got := g.CellAt(0, 0); got != 2 {
+		t.Errorf("CellAt(0, 0) is %d, want 2", got)
+	}
+
+	if got := g.CellAt(3, 3); got != 1 {
+		t.Errorf("CellAt(3, 3) is %d, want 1", got)
+	}
+
+	if got := g.CellAt(-1, 0); got != 0 {
+		t.Errorf("CellAt(-1, 0) is %d, want 0", got)
+	}
+
+	var r table.Reader[int] = g
+
+	var y_row int
+
+	for row := range r.Row() {
+		for x_row, cell := range row {
+			if got := r.CellAt(x_row, y_row); got != cell {
+				t.Errorf("CellAt(%d, %d) is %d but Row yields %d", x_row, y_row, got, cell)
+			}
+		}
+
+		y_row++
+	}
+
+	want := [][]int{
+		{2, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 1},
+		{0, 0, 0, 0},
+		{0, 3, 4, 0},
+	}
+
+	if got := g.Table().FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table is %v, want %v", got, want)
+	}
+}
+
+func TestGrowableTableLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y int
+	}{
+		{"far right", math.MaxInt / 2, 0},
+		{"far left", math.MinInt / 2, 0},
+		{"far below", 0, math.MaxInt / 2},
+		{"last int", math.MaxInt, 0},
+		{"first int", math.MinInt, 0},
+		{"above the cap", 1 << 12, 1 << 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := table.NewGrowableTable[int]()
+			g.Set(0, 0, 1)
+
+			err := g.TrySet(tt.x, tt.y, 2)
+			if err == nil {
+				t.Fatalf("TrySet(%d, %d): got nil, want an error", tt.x, tt.y)
+			}
+
+			// Set ignores the write instead.
+			g.Set(tt.x, tt.y, 2)
+
+			if got, want := g.Bounds(), geom.NewRect(0, 0, 1, 1); got != want {
+				t.Errorf("Bounds is %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	g := table.NewGrowableTable[int]()
+
+	err := g.TrySet(math.MaxInt-1, 0, 1)
+	if err != nil {
+		t.Errorf("TrySet of the last cell: %v", err)
+	}
+}
+
+func TestGrowableTableWriteAt(t *testing.T) {
+	g := table.NewGrowableTable[int]()
+
+	var w table.ReadWriter[int] = g
+
+	g.Set(5, 5, 1)
+	w.WriteAt(1, 0, 2)
+	w.WriteAt(-1, -1, 3)
+
+	if got, want := g.Bounds(), geom.NewRect(4, 4, 3, 2); got != want {
+		t.Fatalf("Bounds is %+v, want %+v", got, want)
+	}
+
+	want := [][]int{
+		{3, 0, 0},
+		{0, 1, 2},
+	}
+
+	if got := g.Table().FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Table is %v, want %v", got, want)
+	}
+
+	if got := w.CellAt(2, 1); got != 2 {
+		t.Errorf("CellAt(2, 1) is %d, want 2", got)
+	}
+}
@@ -1,5 +1,9 @@
 package table
 
+import (
+	"github.com/PlayerR9/table/geom"
+)
+
 // fixVerticalBoundaries is a helper function that fixes the vertical boundaries
 // of a table.
 //
@@ -10,33 +14,30 @@ package table
 //
 // Returns:
 //   - [][]T: The elements of the table with the boundaries fixed.
+//
+// At the end of the function, y is the row of the first returned element, clamped
+// to [0, maxHeight].
 func fixVerticalBoundaries[T any](maxHeight int, elems [][]T, y *int) [][]T {
 	actualY := *y
 
 	if actualY < 0 {
-		newElems := elems[-actualY:]
-		*y = 0
+		elems = elems[min(-actualY, len(elems)):]
+		actualY = 0
+	}
 
-		return newElems
-	} else if actualY >= maxHeight {
-		newElems := elems[:maxHeight-actualY]
+	if actualY >= maxHeight {
 		*y = maxHeight
 
-		return newElems
+		return nil
 	}
 
-	totalHeight := len(elems) + actualY
-	var newElems [][]T
-
-	if totalHeight <= maxHeight {
-		newElems = elems
-		y = &totalHeight
-	} else {
-		newElems = elems[:maxHeight-actualY]
-		*y = maxHeight
+	if actualY+len(elems) > maxHeight {
+		elems = elems[:maxHeight-actualY]
 	}
 
-	return newElems
+	*y = actualY
+
+	return elems
 }
 
 // fixHorizontalBoundaries is a helper function that fixes the horizontal boundaries
@@ -48,47 +49,42 @@ func fixVerticalBoundaries[T any](maxHeight int, elems [][]T, y *int) [][]T {
 //   - x: The x-coordinate to fix the boundaries at.
 //
 // Returns:
-//   - [][]T: The elements of the table with the boundaries fixed.
+//   - [][]T: The elements of the table with the boundaries fixed. The rows that
+//     are entirely out of bounds are nil. The given elements are not modified.
+//
+// At the end of the function, x is the column of the first element of each returned
+// row, clamped to [0, maxWidth].
 func fixHorizontalBoundaries[T any](maxWidth int, elems [][]T, x *int) [][]T {
 	actualX := *x
 
+	var start int
+
 	if actualX < 0 {
-		for i, row := range elems {
-			if -actualX >= len(row) {
-				elems[i] = nil
-			} else {
-				elems[i] = row[-actualX:]
-			}
-		}
+		start = -actualX
+		actualX = 0
+	}
 
-		*x = 0
+	available := max(maxWidth-actualX, 0)
+	newElems := make([][]T, 0, len(elems))
 
-		return elems
-	} else if actualX >= maxWidth {
-		for i, row := range elems {
-			if actualX >= len(row) {
-				elems[i] = nil
-			} else {
-				elems[i] = row[:maxWidth-actualX]
-			}
+	for _, row := range elems {
+		if start >= len(row) || available == 0 {
+			newElems = append(newElems, nil)
+			continue
 		}
 
-		*x = maxWidth
+		row = row[start:]
 
-		return elems
-	}
-
-	for i, row := range elems {
-		totalWidth := len(row) + actualX
-
-		if totalWidth < maxWidth {
-			continue
+		if len(row) > available {
+			row = row[:available]
 		}
 
-		elems[i] = row[:maxWidth-actualX]
+		newElems = append(newElems, row)
 	}
 
-	return elems
+	*x = min(actualX, maxWidth)
+
+	return newElems
 }
 
 // FixBoundaries is a function that fixes the boundaries of a table of elements based
@@ -107,8 +103,16 @@ func fixHorizontalBoundaries[T any](maxWidth int, elems [][]T, x *int) [][]T {
 // Behaviors:
 //   - If maxWidth is less than 0, it is set to 0.
 //   - If maxHeight is less than 0, it is set to 0.
-//   - If elems is empty, nil is returned.
+//   - If elems is empty, nil is returned and both x and y are set to 0.
+//   - If x or y is nil, nil is returned.
+//
+// At the end of the function, x and y are the coordinates of the first element of
+// the returned table, clamped to [0, maxWidth] and [0, maxHeight] respectively.
 func FixBoundaries[T any](maxWidth, maxHeight int, elems [][]T, x, y *int) [][]T {
+	if x == nil || y == nil {
+		return nil
+	}
+
 	if maxWidth < 0 {
 		maxWidth = 0
 	}
@@ -128,3 +132,25 @@ func FixBoundaries[T any](maxWidth, maxHeight int, elems [][]T, x, y *int) [][]T
 
 	return elems
 }
+
+// FixBoundariesRect is the equivalent of FixBoundaries but clips the elements to the
+// given region instead of to a table whose top-left cell is (0, 0).
+//
+// Parameters:
+//   - bounds: The region to clip the elements to. Negative sizes are treated as 0.
+//   - elems: The elements of the table.
+//   - at: The cell where the first element of elems would be placed.
+//
+// Returns:
+//   - [][]T: The elements of the table with the boundaries fixed.
+//   - geom.Point: The cell of the first element of the returned table, clamped to
+//     the region. If elems is empty, it is the top-left cell of the region.
+//
+// See FixBoundaries for more information.
+func FixBoundariesRect[T any](bounds geom.Rect, elems [][]T, at geom.Point) ([][]T, geom.Point) {
+	x, y := at.X-bounds.X, at.Y-bounds.Y
+
+	elems = FixBoundaries(bounds.Width, bounds.Height, elems, &x, &y)
+
+	return elems, geom.Point{X: bounds.X + x, Y: bounds.Y + y}
+}
@@ -0,0 +1,251 @@
+package table
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// HTMLOptions are the options of StringTable.RenderHTML.
+type HTMLOptions struct {
+	// Header tells whether the first row of the table is printed as a <thead>
+	// section made of <th> cells.
+	Header bool
+
+	// Align is the alignment of each column. Columns without an alignment, or
+	// aligned to the left, have no text-align style.
+	Align []Alignment
+
+	// TableClass is the class attribute of the <table> element. Empty means none.
+	TableClass string
+
+	// RowClass returns the class attribute of the <tr> element of the given row.
+	// If nil or if it returns an empty string, the row has no class.
+	RowClass func(y int) string
+
+	// CellClass returns the class attribute of the <td> or <th> element of the
+	// cell at the given coordinates. If nil or if it returns an empty string, the
+	// cell has no class.
+	CellClass func(x, y int) string
+}
+
+// RenderHTML prints the table as an HTML <table> element. The content of the
+// cells is escaped and newlines within cells become <br> elements.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (t StringTable) RenderHTML(w io.Writer, opts HTMLOptions) error {
+	return renderHTML(w, t.table, t.width, opts, nil)
+}
+
+// RenderHTML prints the table as an HTML <table> element, like
+// StringTable.RenderHTML does, with merged cells printed with the colspan and
+// rowspan attributes.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+//
+// Since a cell cannot span both the <thead> and the <tbody> sections, a merged
+// cell of the header row that spans several rows is split in two: the header
+// part holds the content and the body part is left empty.
+func (m MergedTable) RenderHTML(w io.Writer, opts HTMLOptions) error {
+	return renderHTML(w, m.table.table, m.table.width, opts, m.spans)
+}
+
+// renderHTML prints the given cells as an HTML table.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - cells: The cells of the table.
+//   - width: The number of columns of the table.
+//   - opts: The rendering options.
+//   - spans: The merged cells of the table.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+func renderHTML(w io.Writer, cells [][]string, width int, opts HTMLOptions, spans []Span) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	}
+
+	spans = clipSpans(spans, width, len(cells))
+
+	r := htmlRenderer{
+		cells:  cells,
+		opts:   opts,
+		merged: spans,
+		spans:  htmlSpans(spans, opts.Header),
+	}
+
+	bw := bufio.NewWriter(w)
+
+	bw.WriteString("<table")
+	writeHTMLClass(bw, opts.TableClass)
+	bw.WriteString(">\n")
+
+	var start int
+
+	if opts.Header && len(cells) > 0 {
+		bw.WriteString("  <thead>\n")
+		r.writeRow(bw, 0, "th")
+		bw.WriteString("  </thead>\n")
+
+		start = 1
+	}
+
+	if start < len(cells) {
+		bw.WriteString("  <tbody>\n")
+
+		for y := start; y < len(cells); y++ {
+			r.writeRow(bw, y, "td")
+		}
+
+		bw.WriteString("  </tbody>\n")
+	}
+
+	bw.WriteString("</table>\n")
+
+	return bw.Flush()
+}
+
+// htmlRenderer is the state of renderHTML.
+type htmlRenderer struct {
+	cells [][]string
+	opts  HTMLOptions
+
+	// merged are the merged cells of the table, clipped to the table, and spans are
+	// the same cells split at the end of the <thead> section.
+	merged, spans []Span
+}
+
+// writeRow prints a <tr> element.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - y: The index of the row.
+//   - tag: The tag of the cells.
+func (r htmlRenderer) writeRow(w *bufio.Writer, y int, tag string) {
+	w.WriteString("    <tr")
+
+	if r.opts.RowClass != nil {
+		writeHTMLClass(w, r.opts.RowClass(y))
+	}
+
+	w.WriteString(">\n")
+
+	for x, cell := range r.cells[y] {
+		span, _ := spanAt(r.spans, x, y)
+		if span.X != x || span.Y != y {
+			continue
+		}
+
+		w.WriteString("      <")
+		w.WriteString(tag)
+
+		if r.opts.CellClass != nil {
+			writeHTMLClass(w, r.opts.CellClass(x, y))
+		}
+
+		if x < len(r.opts.Align) && r.opts.Align[x] != AlignLeft {
+			w.WriteString(` style="text-align: `)
+			w.WriteString(r.opts.Align[x].String())
+			w.WriteByte('"')
+		}
+
+		if span.Width > 1 {
+			w.WriteString(` colspan="`)
+			w.WriteString(strconv.Itoa(span.Width))
+			w.WriteByte('"')
+		}
+
+		if span.Height > 1 {
+			w.WriteString(` rowspan="`)
+			w.WriteString(strconv.Itoa(span.Height))
+			w.WriteByte('"')
+		}
+
+		w.WriteByte('>')
+
+		// The body part of a split span is covered by the original span.
+		merged, _ := spanAt(r.merged, x, y)
+		if merged.X == x && merged.Y == y {
+			w.WriteString(escapeHTML(cell))
+		}
+
+		w.WriteString("</")
+		w.WriteString(tag)
+		w.WriteString(">\n")
+	}
+
+	w.WriteString("    </tr>\n")
+}
+
+// htmlSpans splits the given spans of the header row that span several rows in
+// two, if the table has a header.
+//
+// Parameters:
+//   - spans: The merged cells of the table, clipped to the table.
+//   - header: Whether the first row of the table is a header row.
+//
+// Returns:
+//   - []Span: The spans.
+func htmlSpans(spans []Span, header bool) []Span {
+	split := make([]Span, 0, len(spans))
+
+	for _, span := range spans {
+		if header && span.Y == 0 && span.Height > 1 {
+			split = append(split, Span{X: span.X, Y: 1, Width: span.Width, Height: span.Height - 1})
+			span.Height = 1
+		}
+
+		split = append(split, span)
+	}
+
+	return split
+}
+
+// writeHTMLClass prints a class attribute.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - class: The value of the attribute. If empty, nothing is printed.
+func writeHTMLClass(w *bufio.Writer, class string) {
+	if class == "" {
+		return
+	}
+
+	w.WriteString(` class="`)
+	w.WriteString(html.EscapeString(class))
+	w.WriteByte('"')
+}
+
+// escapeHTML escapes the content of a cell.
+//
+// Parameters:
+//   - cell: The content of the cell.
+//
+// Returns:
+//   - string: The escaped content, with newlines replaced by <br> elements.
+func escapeHTML(cell string) string {
+	return strings.ReplaceAll(html.EscapeString(cell), "\n", "<br>")
+}
@@ -0,0 +1,94 @@
+package table_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestRenderHTML(t *testing.T) {
+	src := must(table.NewStringTableFromRows([][]string{{"name", "n"}, {"<a&b>", "1\n2"}}))
+
+	opts := table.HTMLOptions{
+		Header:     true,
+		Align:      []table.Alignment{table.AlignLeft, table.AlignRight},
+		TableClass: "t",
+		RowClass: func(y int) string {
+			if y == 1 {
+				return "odd"
+			}
+
+			return ""
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := src.RenderHTML(&buf, opts)
+	if err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+
+	want := strings.Join([]string{
+		`<table class="t">`,
+		`  <thead>`,
+		`    <tr>`,
+		`      <th>name</th>`,
+		`      <th style="text-align: right">n</th>`,
+		`    </tr>`,
+		`  </thead>`,
+		`  <tbody>`,
+		`    <tr class="odd">`,
+		`      <td>&lt;a&amp;b&gt;</td>`,
+		`      <td style="text-align: right">1<br>2</td>`,
+		`    </tr>`,
+		`  </tbody>`,
+		`</table>`,
+		``,
+	}, "\n")
+
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderHTMLSplitsHeaderSpans(t *testing.T) {
+	m := must(table.NewMergedTable(must(table.NewStringTableFromRows([][]string{{"a", "b"}, {"c", "d"}}))))
+
+	err := m.Merge(table.Span{X: 0, Y: 0, Width: 1, Height: 2})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = m.RenderHTML(&buf, table.HTMLOptions{Header: true})
+	if err != nil {
+		t.Fatalf("RenderHTML: %v", err)
+	}
+
+	// The span cannot cross from <thead> to <tbody>; its body part is empty.
+	want := strings.Join([]string{
+		`<table>`,
+		`  <thead>`,
+		`    <tr>`,
+		`      <th>a</th>`,
+		`      <th>b</th>`,
+		`    </tr>`,
+		`  </thead>`,
+		`  <tbody>`,
+		`    <tr>`,
+		`      <td></td>`,
+		`      <td>d</td>`,
+		`    </tr>`,
+		`  </tbody>`,
+		`</table>`,
+		``,
+	}, "\n")
+
+	if got := buf.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+}
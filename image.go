@@ -0,0 +1,377 @@
+package table
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+var (
+	_ draw.Image = (*GrayImage)(nil)
+	_ draw.Image = (*Gray16Image)(nil)
+	_ draw.Image = (*AlphaImage)(nil)
+)
+
+// GrayImage is a draw.Image whose pixels are the cells of a Uint8Table. Each cell
+// is the gray level of a pixel, from black (0) to white (255).
+//
+// The image reads and writes the cells of the table directly, without copying
+// them; thus, changes to the table, resizing included, are seen by the image and
+// vice versa. The top-left pixel of the image is at (0, 0) and, whatever the
+// BoundsPolicy of the table, pixels outside of the bounds are never read from nor
+// written to the table.
+type GrayImage struct {
+	table *Uint8Table
+}
+
+// AsGray creates a new image on top of the given table.
+//
+// Parameters:
+//   - t: The table of the pixels.
+//
+// Returns:
+//   - *GrayImage: The new image.
+//   - error: An error if the image could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If t is nil.
+func AsGray(t *Uint8Table) (*GrayImage, error) {
+	if t == nil {
+		return nil, errors.NewErrNilParameter("t")
+	}
+
+	return &GrayImage{
+		table: t,
+	}, nil
+}
+
+// Table returns the table of the pixels.
+//
+// Returns:
+//   - *Uint8Table: The table. Never nil.
+func (img GrayImage) Table() *Uint8Table {
+	return img.table
+}
+
+// ColorModel implements the image.Image interface.
+func (img GrayImage) ColorModel() color.Model {
+	return color.GrayModel
+}
+
+// Bounds implements the image.Image interface.
+func (img GrayImage) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.table.width, img.table.height)
+}
+
+// At implements the image.Image interface.
+func (img GrayImage) At(x, y int) color.Color {
+	return img.GrayAt(x, y)
+}
+
+// GrayAt returns the color of the pixel at the given coordinates, without
+// allocating. Out-of-bounds pixels are black.
+//
+// Parameters:
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//
+// Returns:
+//   - color.Gray: The color of the pixel.
+func (img GrayImage) GrayAt(x, y int) color.Gray {
+	return color.Gray{Y: pixelAt(img.table.table, img.table.width, img.table.height, x, y)}
+}
+
+// Set implements the draw.Image interface. The color is converted with the
+// color model of the image and out-of-bounds pixels are ignored.
+func (img GrayImage) Set(x, y int, c color.Color) {
+	img.SetGray(x, y, color.Gray{Y: GrayLevel(c)})
+}
+
+// SetGray sets the color of the pixel at the given coordinates. Out-of-bounds
+// pixels are ignored.
+//
+// Parameters:
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//   - c: The color of the pixel.
+func (img GrayImage) SetGray(x, y int, c color.Gray) {
+	setPixel(img.table.table, img.table.width, img.table.height, x, y, c.Y)
+}
+
+// Gray16Image is a draw.Image whose pixels are the cells of a Uint16Table. Each
+// cell is the gray level of a pixel, from black (0) to white (65535). Like
+// GrayImage, it shares the cells of its table.
+type Gray16Image struct {
+	table *Uint16Table
+}
+
+// AsGray16 creates a new image on top of the given table.
+//
+// Parameters:
+//   - t: The table of the pixels.
+//
+// Returns:
+//   - *Gray16Image: The new image.
+//   - error: An error if the image could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If t is nil.
+func AsGray16(t *Uint16Table) (*Gray16Image, error) {
+	if t == nil {
+		return nil, errors.NewErrNilParameter("t")
+	}
+
+	return &Gray16Image{
+		table: t,
+	}, nil
+}
+
+// Table returns the table of the pixels.
+//
+// Returns:
+//   - *Uint16Table: The table. Never nil.
+func (img Gray16Image) Table() *Uint16Table {
+	return img.table
+}
+
+// ColorModel implements the image.Image interface.
+func (img Gray16Image) ColorModel() color.Model {
+	return color.Gray16Model
+}
+
+// Bounds implements the image.Image interface.
+func (img Gray16Image) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.table.width, img.table.height)
+}
+
+// At implements the image.Image interface.
+func (img Gray16Image) At(x, y int) color.Color {
+	return img.Gray16At(x, y)
+}
+
+// Gray16At returns the color of the pixel at the given coordinates, without
+// allocating. Out-of-bounds pixels are black.
+//
+// Parameters:
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//
+// Returns:
+//   - color.Gray16: The color of the pixel.
+func (img Gray16Image) Gray16At(x, y int) color.Gray16 {
+	return color.Gray16{Y: pixelAt(img.table.table, img.table.width, img.table.height, x, y)}
+}
+
+// Set implements the draw.Image interface. The color is converted with the
+// color model of the image and out-of-bounds pixels are ignored.
+func (img Gray16Image) Set(x, y int, c color.Color) {
+	img.SetGray16(x, y, color.Gray16{Y: Gray16Level(c)})
+}
+
+// SetGray16 sets the color of the pixel at the given coordinates. Out-of-bounds
+// pixels are ignored.
+//
+// Parameters:
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//   - c: The color of the pixel.
+func (img Gray16Image) SetGray16(x, y int, c color.Gray16) {
+	setPixel(img.table.table, img.table.width, img.table.height, x, y, c.Y)
+}
+
+// AlphaImage is a draw.Image whose pixels are the cells of a BoolTable. Each cell
+// tells whether a pixel is opaque (true) or transparent (false); that is, the
+// table is a mask. Like GrayImage, it shares the cells of its table.
+type AlphaImage struct {
+	table *BoolTable
+}
+
+// AsAlpha creates a new image on top of the given table.
+//
+// Parameters:
+//   - t: The table of the pixels.
+//
+// Returns:
+//   - *AlphaImage: The new image.
+//   - error: An error if the image could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If t is nil.
+func AsAlpha(t *BoolTable) (*AlphaImage, error) {
+	if t == nil {
+		return nil, errors.NewErrNilParameter("t")
+	}
+
+	return &AlphaImage{
+		table: t,
+	}, nil
+}
+
+// Table returns the table of the pixels.
+//
+// Returns:
+//   - *BoolTable: The table. Never nil.
+func (img AlphaImage) Table() *BoolTable {
+	return img.table
+}
+
+// ColorModel implements the image.Image interface.
+func (img AlphaImage) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+// Bounds implements the image.Image interface.
+func (img AlphaImage) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.table.width, img.table.height)
+}
+
+// At implements the image.Image interface.
+func (img AlphaImage) At(x, y int) color.Color {
+	return img.AlphaAt(x, y)
+}
+
+// AlphaAt returns the color of the pixel at the given coordinates, without
+// allocating. Out-of-bounds pixels are transparent.
+//
+// Parameters:
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//
+// Returns:
+//   - color.Alpha: The color of the pixel.
+func (img AlphaImage) AlphaAt(x, y int) color.Alpha {
+	if pixelAt(img.table.table, img.table.width, img.table.height, x, y) {
+		return color.Alpha{A: 0xff}
+	}
+
+	return color.Alpha{}
+}
+
+// Set implements the draw.Image interface. The color is converted with the
+// color model of the image and out-of-bounds pixels are ignored.
+func (img AlphaImage) Set(x, y int, c color.Color) {
+	img.SetAlpha(x, y, color.AlphaModel.Convert(c).(color.Alpha))
+}
+
+// SetAlpha sets the color of the pixel at the given coordinates. Out-of-bounds
+// pixels are ignored.
+//
+// Parameters:
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//   - c: The color of the pixel.
+func (img AlphaImage) SetAlpha(x, y int, c color.Alpha) {
+	setPixel(img.table.table, img.table.width, img.table.height, x, y, c.A >= 0x80)
+}
+
+// pixelAt returns the cell of a pixel. Unlike CellAt, it ignores the BoundsPolicy
+// of the table.
+//
+// Parameters:
+//   - cells: The cells of the table.
+//   - width: The width of the table.
+//   - height: The height of the table.
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//
+// Returns:
+//   - T: The cell of the pixel. The zero value of T if the pixel is out of bounds.
+func pixelAt[T any](cells [][]T, width, height, x, y int) T {
+	if x < 0 || x >= width || y < 0 || y >= height {
+		return *new(T)
+	}
+
+	return cells[y][x]
+}
+
+// setPixel sets the cell of a pixel. Unlike WriteAt, it ignores the BoundsPolicy of
+// the table; out-of-bounds pixels are ignored.
+//
+// Parameters:
+//   - cells: The cells of the table.
+//   - width: The width of the table.
+//   - height: The height of the table.
+//   - x: The x-coordinate of the pixel.
+//   - y: The y-coordinate of the pixel.
+//   - cell: The cell of the pixel.
+func setPixel[T any](cells [][]T, width, height, x, y int, cell T) {
+	if x < 0 || x >= width || y < 0 || y >= height {
+		return
+	}
+
+	cells[y][x] = cell
+}
+
+// FromImage creates a table whose cells are the pixels of the given image. The
+// top-left pixel of the image becomes the cell at (0, 0).
+//
+// Parameters:
+//   - img: The image.
+//   - cell: The function that converts the color of a pixel into a cell.
+//
+// Returns:
+//   - *Table[T]: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If img or cell is nil.
+func FromImage[T any](img image.Image, cell func(c color.Color) T) (*Table[T], error) {
+	if img == nil {
+		return nil, errors.NewErrNilParameter("img")
+	} else if cell == nil {
+		return nil, errors.NewErrNilParameter("cell")
+	}
+
+	bounds := img.Bounds()
+
+	t, err := NewTable[T](bounds.Dx(), bounds.Dy())
+	if err != nil {
+		return nil, err
+	}
+
+	for y, row := range t.table {
+		for x := range row {
+			row[x] = cell(img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return t, nil
+}
+
+// GrayLevel converts a color to a gray level, from black (0) to white (255). It is
+// the conversion of GrayImage and of the Uint8Table and ByteTable FromImage functions.
+//
+// Parameters:
+//   - c: The color to convert.
+//
+// Returns:
+//   - uint8: The gray level.
+func GrayLevel(c color.Color) uint8 {
+	return color.GrayModel.Convert(c).(color.Gray).Y
+}
+
+// Gray16Level converts a color to a 16-bit gray level, from black (0) to white
+// (65535). It is the conversion of Gray16Image and of Uint16TableFromImage.
+//
+// Parameters:
+//   - c: The color to convert.
+//
+// Returns:
+//   - uint16: The gray level.
+func Gray16Level(c color.Color) uint16 {
+	return color.Gray16Model.Convert(c).(color.Gray16).Y
+}
+
+// IsOpaque checks whether a color is at least half opaque. It is the conversion of
+// AlphaImage and of BoolTableFromImage.
+//
+// Parameters:
+//   - c: The color to check.
+//
+// Returns:
+//   - bool: True if the color is at least half opaque, false otherwise.
+func IsOpaque(c color.Color) bool {
+	return color.AlphaModel.Convert(c).(color.Alpha).A >= 0x80
+}
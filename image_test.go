@@ -0,0 +1,84 @@
+package table_test
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestImageIgnoresBoundsPolicy(t *testing.T) {
+	for _, policy := range []table.BoundsPolicy{table.BoundsClip, table.BoundsWrap, table.BoundsClamp, table.BoundsGrow} {
+		t.Run(policy.String(), func(t *testing.T) {
+			tbl := must(table.NewUint8TableFromRows([][]uint8{{10, 20}, {30, 40}}))
+
+			err := tbl.SetBoundsPolicy(policy)
+			if err != nil {
+				t.Fatalf("SetBoundsPolicy: %v", err)
+			}
+
+			img := must(table.AsGray(tbl))
+
+			for _, p := range [][2]int{{-1, 0}, {2, 0}, {0, 2}, {5, 5}} {
+				if got := img.GrayAt(p[0], p[1]); got.Y != 0 {
+					t.Errorf("GrayAt(%d, %d) is %d, want 0", p[0], p[1], got.Y)
+				}
+
+				img.Set(p[0], p[1], color.White)
+			}
+
+			if tbl.Width() != 2 || tbl.Height() != 2 {
+				t.Fatalf("table was resized to %dx%d", tbl.Width(), tbl.Height())
+			}
+
+			want := [][]uint8{{10, 20}, {30, 40}}
+			for y, row := range want {
+				for x, cell := range row {
+					if got := img.GrayAt(x, y).Y; got != cell {
+						t.Errorf("GrayAt(%d, %d) is %d, want %d", x, y, got, cell)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAlphaImage(t *testing.T) {
+	tbl := must(table.NewBoolTable(2, 1))
+	img := must(table.AsAlpha(tbl))
+
+	img.Set(1, 0, color.Alpha{A: 0xc0})
+	img.Set(0, 0, color.Alpha{A: 0x40})
+
+	if !tbl.CellAt(1, 0) || tbl.CellAt(0, 0) {
+		t.Errorf("got %v, want [[false true]]", tbl.FullTable())
+	}
+}
+
+func TestTableFromImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(3, 4, 5, 5))
+	src.Set(3, 4, color.White)
+	src.Set(4, 4, color.RGBA{A: 0x40})
+
+	gray := must(table.Uint8TableFromImage(src))
+	if got, want := gray.FullTable(), [][]uint8{{255, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Uint8TableFromImage: got %v, want %v", got, want)
+	}
+
+	gray16 := must(table.Uint16TableFromImage(must(table.AsGray(gray))))
+	if got, want := gray16.FullTable(), [][]uint16{{65535, 0}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Uint16TableFromImage: got %v, want %v", got, want)
+	}
+
+	mask := must(table.BoolTableFromImage(src))
+	if got, want := mask.FullTable(), [][]bool{{true, false}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BoolTableFromImage: got %v, want %v", got, want)
+	}
+
+	_, err := table.ByteTableFromImage(nil)
+	if err == nil {
+		t.Errorf("ByteTableFromImage(nil) succeeded")
+	}
+}
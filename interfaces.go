@@ -0,0 +1,64 @@
+package table
+
+import (
+	"iter"
+)
+
+// Reader is the interface that wraps the read-only methods of a table.
+type Reader[T any] interface {
+	// Width returns the width of the table.
+	//
+	// Returns:
+	//   - int: The width of the table. Never negative.
+	Width() int
+
+	// Height returns the height of the table.
+	//
+	// Returns:
+	//   - int: The height of the table. Never negative.
+	Height() int
+
+	// CellAt returns the cell at the given coordinates in the table. However, out-of-bounds
+	// coordinates return the zero value of T unless the table maps them to a cell; see
+	// BoundsPolicy.
+	//
+	// Parameters:
+	//   - x: The x-coordinate of the cell.
+	//   - y: The y-coordinate of the cell.
+	//
+	// Returns:
+	//   - T: The cell at the given coordinates.
+	CellAt(x, y int) T
+
+	// Cell returns an iterator that scans the table cell by cell, row by row.
+	//
+	// Returns:
+	//   - iter.Seq[T]: The iterator. Never returns nil.
+	Cell() iter.Seq[T]
+
+	// Row returns an iterator that scans the table row by row. Whether the yielded
+	// slices share their memory with the table depends on the implementation; they
+	// must not be retained nor modified.
+	//
+	// Returns:
+	//   - iter.Seq[[]T]: The iterator. Never returns nil.
+	Row() iter.Seq[[]T]
+}
+
+// Writer is the interface that wraps the WriteAt method of a table.
+type Writer[T any] interface {
+	// WriteAt writes a cell to the table at the given coordinates. However, out-of-bounds
+	// coordinates do nothing unless the table maps them to a cell; see BoundsPolicy.
+	//
+	// Parameters:
+	//   - x: The x-coordinate of the cell.
+	//   - y: The y-coordinate of the cell.
+	//   - cell: The cell to write to the table.
+	WriteAt(x, y int, cell T)
+}
+
+// ReadWriter is the interface that groups the Reader and Writer interfaces.
+type ReadWriter[T any] interface {
+	Reader[T]
+	Writer[T]
+}
@@ -0,0 +1,165 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// TableJSON is the JSON representation of a table. It is used by the MarshalJSON
+// and UnmarshalJSON methods of every table.
+//
+// Example:
+//
+//	{"width":3,"height":2,"cells":[[1,2,3],[4,5,6]]}
+type TableJSON[T any] struct {
+	// Width is the number of cells of each row.
+	Width int `json:"width"`
+
+	// Height is the number of rows.
+	Height int `json:"height"`
+
+	// Cells are the rows of cells.
+	Cells [][]T `json:"cells"`
+}
+
+// Validate checks that the cells match the declared dimensions.
+//
+// Returns:
+//   - error: An error if the representation is not valid.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the width or the height is negative.
+//   - error: If the number of rows is not the height.
+//   - *ErrCell: If a row does not have as many cells as the width.
+func (tj TableJSON[T]) Validate() error {
+	if tj.Width < 0 {
+		return errors.NewErrInvalidParameter("width", errors.NewErrGTE(0))
+	} else if tj.Height < 0 {
+		return errors.NewErrInvalidParameter("height", errors.NewErrGTE(0))
+	}
+
+	if len(tj.Cells) != tj.Height {
+		return fmt.Errorf("expected %d rows, got %d", tj.Height, len(tj.Cells))
+	}
+
+	for y, row := range tj.Cells {
+		if len(row) != tj.Width {
+			return NewErrCell(min(len(row), tj.Width), y, fmt.Errorf("expected %d cells, got %d", tj.Width, len(row)))
+		}
+	}
+
+	return nil
+}
+
+// ConvertCells returns a copy of the given cells converted with the given function.
+// It is used by the generated JSON methods of the tables whose cells have no JSON
+// encoding of their own.
+//
+// Parameters:
+//   - cells: The cells to convert.
+//   - fn: The conversion function. Assumed not to be nil.
+//
+// Returns:
+//   - [][]U: The converted cells. Never returns nil.
+func ConvertCells[T, U any](cells [][]T, fn func(T) U) [][]U {
+	converted := make([][]U, 0, len(cells))
+
+	for _, row := range cells {
+		new_row := make([]U, 0, len(row))
+
+		for _, cell := range row {
+			new_row = append(new_row, fn(cell))
+		}
+
+		converted = append(converted, new_row)
+	}
+
+	return converted
+}
+
+// Complex64JSON converts a complex64 cell to its JSON representation, [re, im].
+//
+// Parameters:
+//   - c: The cell to convert.
+//
+// Returns:
+//   - [2]float32: The real and imaginary parts of c.
+func Complex64JSON(c complex64) [2]float32 {
+	return [2]float32{real(c), imag(c)}
+}
+
+// Complex64FromJSON is the inverse of Complex64JSON.
+//
+// Parameters:
+//   - v: The real and imaginary parts of the cell.
+//
+// Returns:
+//   - complex64: The cell.
+func Complex64FromJSON(v [2]float32) complex64 {
+	return complex(v[0], v[1])
+}
+
+// Complex128JSON converts a complex128 cell to its JSON representation, [re, im].
+//
+// Parameters:
+//   - c: The cell to convert.
+//
+// Returns:
+//   - [2]float64: The real and imaginary parts of c.
+func Complex128JSON(c complex128) [2]float64 {
+	return [2]float64{real(c), imag(c)}
+}
+
+// Complex128FromJSON is the inverse of Complex128JSON.
+//
+// Parameters:
+//   - v: The real and imaginary parts of the cell.
+//
+// Returns:
+//   - complex128: The cell.
+func Complex128FromJSON(v [2]float64) complex128 {
+	return complex(v[0], v[1])
+}
+
+// ErrorJSON converts an error cell to its JSON representation; that is, its message,
+// or null if the cell is nil.
+//
+// Parameters:
+//   - err: The cell to convert.
+//
+// Returns:
+//   - *string: The message of err. Nil if err is nil.
+func ErrorJSON(err error) *string {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+
+	return &msg
+}
+
+// ErrorFromJSON is the inverse of ErrorJSON. Only the message of the errors is
+// preserved.
+//
+// Parameters:
+//   - msg: The message of the error. Nil for a nil cell.
+//
+// Returns:
+//   - error: The cell. Nil if msg is nil.
+func ErrorFromJSON(msg *string) error {
+	if msg == nil {
+		return nil
+	}
+
+	return jsonError(*msg)
+}
+
+// jsonError is an error decoded from JSON, of which only the message is known.
+type jsonError string
+
+// Error implements the error interface.
+func (e jsonError) Error() string {
+	return string(e)
+}
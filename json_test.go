@@ -0,0 +1,91 @@
+package table_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	testRoundTrip(t, jsonCodec, []roundTripCase{
+		{name: "Table", src: must(table.NewTableFromRows([][]string{{"a", "b"}, {"", "d"}}))},
+		{name: "BoolTable", src: must(table.NewBoolTableFromRows([][]bool{{true, false}, {false, true}}))},
+		{name: "ByteTable", src: must(table.NewByteTableFromRows([][]byte{{0, 255}, {1, 2}}))},
+		{name: "Complex64Table", src: must(table.NewComplex64TableFromRows([][]complex64{{1 + 2i, 0}, {-3.5i, 4}}))},
+		{name: "Complex128Table", src: must(table.NewComplex128TableFromRows([][]complex128{{1 + 2i, 0}, {-3.5i, 4}}))},
+		{name: "ErrorTable", src: must(table.NewErrorTableFromRows([][]error{{errors.New("boom"), nil}, {nil, errors.New("")}})), equal: equalErrors},
+		{name: "Float32Table", src: must(table.NewFloat32TableFromRows([][]float32{{0.1, -2}, {3e30, 0}}))},
+		{name: "Float64Table", src: must(table.NewFloat64TableFromRows([][]float64{{0.1, -2}, {3e300, 0}}))},
+		{name: "IntTable", src: must(table.NewIntTableFromRows([][]int{{-1, 2}, {3, math.MaxInt}}))},
+		{name: "Int8Table", src: must(table.NewInt8TableFromRows([][]int8{{-128, 127}}))},
+		{name: "Int16Table", src: must(table.NewInt16TableFromRows([][]int16{{-32768, 32767}}))},
+		{name: "Int32Table", src: must(table.NewInt32TableFromRows([][]int32{{-1 << 31, 1<<31 - 1}}))},
+		{name: "Int64Table", src: must(table.NewInt64TableFromRows([][]int64{{-1 << 63, 1<<63 - 1}}))},
+		{name: "RuneTable", src: must(table.NewRuneTableFromRows([][]rune{[]rune("a界"), {0, ' '}}))},
+		{name: "StringTable", src: must(table.NewStringTableFromRows([][]string{{"a\"b", ""}, {"<>", "\n"}}))},
+		{name: "UintTable", src: must(table.NewUintTableFromRows([][]uint{{0, math.MaxUint}}))},
+		{name: "Uint8Table", src: must(table.NewUint8TableFromRows([][]uint8{{0, 255}}))},
+		{name: "Uint16Table", src: must(table.NewUint16TableFromRows([][]uint16{{0, 65535}}))},
+		{name: "Uint32Table", src: must(table.NewUint32TableFromRows([][]uint32{{0, 1<<32 - 1}}))},
+		{name: "Uint64Table", src: must(table.NewUint64TableFromRows([][]uint64{{0, 1<<64 - 1}}))},
+		{name: "UintptrTable", src: must(table.NewUintptrTableFromRows([][]uintptr{{0, 1 << 31}}))},
+		{name: "empty Complex128Table", src: must(table.NewComplex128Table(0, 0))},
+		{name: "empty ErrorTable", src: must(table.NewErrorTable(0, 0))},
+		{name: "empty IntTable", src: must(table.NewIntTable(0, 0))},
+	})
+}
+
+// equalErrors compares two rows of errors by their messages.
+func equalErrors(got, want any) bool {
+	a, b := got.([][]error), want.([][]error)
+
+	for y := range a {
+		for x := range a[y] {
+			if (a[y][x] == nil) != (b[y][x] == nil) {
+				return false
+			} else if a[y][x] != nil && a[y][x].Error() != b[y][x].Error() {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestJSONEncoding(t *testing.T) {
+	c := must(table.NewComplex128TableFromRows([][]complex128{{1 + 2i}}))
+	e := must(table.NewErrorTableFromRows([][]error{{errors.New("boom"), nil}}))
+
+	tests := []struct {
+		name string
+		src  json.Marshaler
+		want string
+	}{
+		{"complex", c, `{"width":1,"height":1,"cells":[[[1,2]]]}`},
+		{"error", e, `{"width":2,"height":1,"cells":[["boom",null]]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != tt.want {
+				t.Errorf("got %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table/geom"
+)
+
+// Layer is a named table that is composited, together with other layers, by a
+// LayerStack.
+type Layer[T any] struct {
+	name        string
+	content     Reader[T]
+	x, y        int
+	z           int
+	visible     bool
+	transparent func(cell T) bool
+}
+
+// NewLayer creates a new visible layer with the given name and content. The layer
+// is placed at the origin, has a z-index of 0 and is fully opaque.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - content: The cells of the layer.
+//
+// Returns:
+//   - *Layer[T]: The new layer.
+//   - error: An error if the layer could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the content is nil.
+func NewLayer[T any](name string, content Reader[T]) (*Layer[T], error) {
+	if content == nil {
+		return nil, errors.NewErrNilParameter("content")
+	}
+
+	return &Layer[T]{
+		name:    name,
+		content: content,
+		visible: true,
+	}, nil
+}
+
+// Name returns the name of the layer.
+//
+// Returns:
+//   - string: The name of the layer.
+func (l Layer[T]) Name() string {
+	return l.name
+}
+
+// Content returns the cells of the layer.
+//
+// Returns:
+//   - Reader[T]: The cells of the layer. Never nil.
+func (l Layer[T]) Content() Reader[T] {
+	return l.content
+}
+
+// Offset returns the coordinates, in the destination table, of the top-left
+// cell of the layer.
+//
+// Returns:
+//   - int: The x-coordinate of the layer.
+//   - int: The y-coordinate of the layer.
+func (l Layer[T]) Offset() (int, int) {
+	return l.x, l.y
+}
+
+// Z returns the z-index of the layer. Layers with a higher z-index are drawn
+// on top of layers with a lower one.
+//
+// Returns:
+//   - int: The z-index of the layer.
+func (l Layer[T]) Z() int {
+	return l.z
+}
+
+// IsVisible checks whether the layer is drawn by the stack.
+//
+// Returns:
+//   - bool: True if the layer is visible, false otherwise.
+func (l Layer[T]) IsVisible() bool {
+	return l.visible
+}
+
+// IsTransparentAt checks whether the cell of the layer at the given destination
+// coordinates lets the layers below it show through. Cells outside of the layer
+// are always transparent.
+//
+// Parameters:
+//   - x: The x-coordinate in the destination table.
+//   - y: The y-coordinate in the destination table.
+//
+// Returns:
+//   - bool: True if the cell is transparent, false otherwise.
+func (l Layer[T]) IsTransparentAt(x, y int) bool {
+	x -= l.x
+	y -= l.y
+
+	if x < 0 || x >= l.content.Width() || y < 0 || y >= l.content.Height() {
+		return true
+	}
+
+	return l.transparent != nil && l.transparent(l.content.CellAt(x, y))
+}
+
+// SetOffset moves the layer so that its top-left cell is at the given coordinates.
+//
+// Parameters:
+//   - x: The x-coordinate of the layer.
+//   - y: The y-coordinate of the layer.
+func (l *Layer[T]) SetOffset(x, y int) {
+	if l == nil {
+		return
+	}
+
+	l.x, l.y = x, y
+}
+
+// SetTransparency sets the predicate that tells which cells of the layer are
+// transparent. A nil predicate makes the whole layer opaque.
+//
+// Parameters:
+//   - fn: The transparency predicate.
+func (l *Layer[T]) SetTransparency(fn func(cell T) bool) {
+	if l == nil {
+		return
+	}
+
+	l.transparent = fn
+}
+
+// LayerStack is an ordered collection of uniquely named layers that can be
+// flattened into a single table.
+type LayerStack[T any] struct {
+	// layers are the layers of the stack, sorted from the bottom-most to the
+	// top-most one. Layers with the same z-index keep their insertion order.
+	layers []*Layer[T]
+}
+
+// NewLayerStack creates a new empty layer stack.
+//
+// Returns:
+//   - *LayerStack[T]: The new layer stack. Never returns nil.
+func NewLayerStack[T any]() *LayerStack[T] {
+	return &LayerStack[T]{}
+}
+
+// Len returns the number of layers in the stack.
+//
+// Returns:
+//   - int: The number of layers. Never negative.
+func (s LayerStack[T]) Len() int {
+	return len(s.layers)
+}
+
+// Add adds a layer on top of all the layers with the same z-index.
+//
+// Parameters:
+//   - layer: The layer to add.
+//
+// Returns:
+//   - error: An error if the layer could not be added.
+//
+// Errors:
+//   - errors.NilReceiver: If the stack is nil.
+//   - *errors.ErrInvalidParameter: If the layer is nil or its name is already used.
+func (s *LayerStack[T]) Add(layer *Layer[T]) error {
+	if s == nil {
+		return errors.NilReceiver
+	} else if layer == nil {
+		return errors.NewErrNilParameter("layer")
+	}
+
+	if s.indexOf(layer.name) != -1 {
+		return errors.NewErrInvalidParameter("layer", fmt.Errorf("layer %q already exists", layer.name))
+	}
+
+	s.layers = append(s.layers, layer)
+	s.sort()
+
+	return nil
+}
+
+// Get returns the layer with the given name.
+//
+// Parameters:
+//   - name: The name of the layer.
+//
+// Returns:
+//   - *Layer[T]: The layer. Nil if no layer has the given name.
+//   - bool: True if the layer was found, false otherwise.
+func (s LayerStack[T]) Get(name string) (*Layer[T], bool) {
+	idx := s.indexOf(name)
+	if idx == -1 {
+		return nil, false
+	}
+
+	return s.layers[idx], true
+}
+
+// Remove removes the layer with the given name.
+//
+// Parameters:
+//   - name: The name of the layer.
+//
+// Returns:
+//   - bool: True if the layer was removed, false if no layer has the given name.
+func (s *LayerStack[T]) Remove(name string) bool {
+	if s == nil {
+		return false
+	}
+
+	idx := s.indexOf(name)
+	if idx == -1 {
+		return false
+	}
+
+	s.layers = slices.Delete(s.layers, idx, idx+1)
+
+	return true
+}
+
+// SetZ changes the z-index of the layer with the given name. The layer is moved
+// on top of all the other layers with the same z-index.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - z: The new z-index.
+//
+// Returns:
+//   - bool: True if the layer was found, false otherwise.
+func (s *LayerStack[T]) SetZ(name string, z int) bool {
+	if s == nil {
+		return false
+	}
+
+	idx := s.indexOf(name)
+	if idx == -1 {
+		return false
+	}
+
+	layer := s.layers[idx]
+	layer.z = z
+
+	s.layers = append(slices.Delete(s.layers, idx, idx+1), layer)
+	s.sort()
+
+	return true
+}
+
+// SetVisible shows or hides the layer with the given name.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - visible: Whether the layer should be drawn.
+//
+// Returns:
+//   - bool: True if the layer was found, false otherwise.
+func (s *LayerStack[T]) SetVisible(name string, visible bool) bool {
+	if s == nil {
+		return false
+	}
+
+	idx := s.indexOf(name)
+	if idx == -1 {
+		return false
+	}
+
+	s.layers[idx].visible = visible
+
+	return true
+}
+
+// Layers returns an iterator over the layers of the stack, from the bottom-most
+// to the top-most one. Hidden layers are included.
+//
+// Returns:
+//   - iter.Seq[*Layer[T]]: The iterator. Never returns nil.
+func (s LayerStack[T]) Layers() iter.Seq[*Layer[T]] {
+	fn := func(yield func(*Layer[T]) bool) {
+		for _, layer := range s.layers {
+			if !yield(layer) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Compose flattens the visible layers into the given table, from the bottom-most
+// to the top-most one. Transparent cells are skipped so that whatever was
+// drawn below them shows through, and cells that fall outside of the destination
+// are ignored, whatever its BoundsPolicy.
+//
+// Parameters:
+//   - dst: The table to draw the layers into.
+//
+// If dst is nil, nothing happens.
+func (s LayerStack[T]) Compose(dst ReadWriter[T]) {
+	if dst == nil {
+		return
+	}
+
+	bounds := geom.NewRect(0, 0, dst.Width(), dst.Height())
+
+	for _, layer := range s.layers {
+		if !layer.visible {
+			continue
+		}
+
+		area := geom.NewRect(layer.x, layer.y, layer.content.Width(), layer.content.Height())
+		clip := area.Intersect(bounds)
+
+		for y := clip.Y; y < clip.Y+clip.Height; y++ {
+			for x := clip.X; x < clip.X+clip.Width; x++ {
+				cell := layer.content.CellAt(x-layer.x, y-layer.y)
+
+				if layer.transparent != nil && layer.transparent(cell) {
+					continue
+				}
+
+				dst.WriteAt(x, y, cell)
+			}
+		}
+	}
+}
+
+// HitTest returns the layer that owns the cell at the given destination
+// coordinates; that is, the top-most visible layer whose cell at those
+// coordinates is not transparent.
+//
+// Parameters:
+//   - x: The x-coordinate in the destination table.
+//   - y: The y-coordinate in the destination table.
+//
+// Returns:
+//   - *Layer[T]: The layer that owns the cell. Nil if no layer does.
+//   - bool: True if a layer owns the cell, false otherwise.
+func (s LayerStack[T]) HitTest(x, y int) (*Layer[T], bool) {
+	for i := len(s.layers) - 1; i >= 0; i-- {
+		layer := s.layers[i]
+
+		if layer.visible && !layer.IsTransparentAt(x, y) {
+			return layer, true
+		}
+	}
+
+	return nil, false
+}
+
+// indexOf returns the index of the layer with the given name.
+//
+// Parameters:
+//   - name: The name of the layer.
+//
+// Returns:
+//   - int: The index of the layer. -1 if no layer has the given name.
+func (s LayerStack[T]) indexOf(name string) int {
+	return slices.IndexFunc(s.layers, func(layer *Layer[T]) bool {
+		return layer.name == name
+	})
+}
+
+// sort sorts the layers by z-index while preserving the insertion order of
+// layers with the same z-index.
+func (s *LayerStack[T]) sort() {
+	slices.SortStableFunc(s.layers, func(a, b *Layer[T]) int {
+		return cmp.Compare(a.z, b.z)
+	})
+}
@@ -0,0 +1,116 @@
+package table_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+// newTestLayer creates a layer whose content are the given rows, at the given
+// offset. Cells equal to '.' are transparent.
+func newTestLayer(name string, rows []string, x, y int) *table.Layer[rune] {
+	cells := make([][]rune, 0, len(rows))
+
+	for _, row := range rows {
+		cells = append(cells, []rune(row))
+	}
+
+	layer := must(table.NewLayer[rune](name, must(table.NewRuneTableFromRows(cells))))
+	layer.SetOffset(x, y)
+	layer.SetTransparency(func(cell rune) bool { return cell == '.' })
+
+	return layer
+}
+
+// composeRows composes the stack into a table of the given size, filled with '-'
+// and using the given policy, and returns its rows.
+func composeRows(s *table.LayerStack[rune], width, height int, policy table.BoundsPolicy) []string {
+	dst := must(table.NewRuneTable(width, height))
+
+	err := dst.SetBoundsPolicy(policy)
+	if err != nil {
+		panic(err)
+	}
+
+	dst.FillRect(dst.Bounds(), '-')
+
+	s.Compose(dst)
+
+	rows := make([]string, 0, height)
+
+	for row := range dst.Row() {
+		rows = append(rows, string(row))
+	}
+
+	return rows
+}
+
+func TestLayerStackCompose(t *testing.T) {
+	s := table.NewLayerStack[rune]()
+
+	_ = s.Add(newTestLayer("top", []string{"ab", ".c"}, 1, 0))
+	_ = s.Add(newTestLayer("bottom", []string{"xxx", "xxx"}, 0, 1))
+	s.SetZ("top", 1)
+
+	got := composeRows(s, 3, 3, table.BoundsClip)
+	want := []string{"-ab", "xxc", "xxx"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	s.SetVisible("top", false)
+
+	got = composeRows(s, 3, 3, table.BoundsClip)
+	want = []string{"---", "xxx", "xxx"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q with the top layer hidden, want %q", got, want)
+	}
+}
+
+func TestLayerStackComposeClips(t *testing.T) {
+	policies := []table.BoundsPolicy{table.BoundsClip, table.BoundsWrap, table.BoundsClamp, table.BoundsGrow}
+
+	for _, policy := range policies {
+		t.Run(policy.String(), func(t *testing.T) {
+			s := table.NewLayerStack[rune]()
+
+			_ = s.Add(newTestLayer("layer", []string{"abc", "def"}, -1, 1))
+
+			got := composeRows(s, 2, 2, policy)
+			want := []string{"--", "bc"}
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLayerStackHitTest(t *testing.T) {
+	s := table.NewLayerStack[rune]()
+
+	_ = s.Add(newTestLayer("bottom", []string{"xx"}, 0, 0))
+	_ = s.Add(newTestLayer("top", []string{".a"}, 0, 0))
+
+	tests := []struct {
+		x, y int
+		want string
+	}{
+		{0, 0, "bottom"},
+		{1, 0, "top"},
+	}
+
+	for _, tt := range tests {
+		layer, ok := s.HitTest(tt.x, tt.y)
+		if !ok || layer.Name() != tt.want {
+			t.Errorf("HitTest(%d, %d) = %v, %t, want %s", tt.x, tt.y, layer, ok, tt.want)
+		}
+	}
+
+	if layer, ok := s.HitTest(2, 0); ok {
+		t.Errorf("HitTest(2, 0) = %s, want no layer", layer.Name())
+	}
+}
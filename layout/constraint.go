@@ -0,0 +1,218 @@
+package layout
+
+import (
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// constraintKind is the kind of a constraint.
+type constraintKind int
+
+const (
+	// flexKind is a constraint that shares the remaining space with its siblings.
+	flexKind constraintKind = iota
+
+	// fixedKind is a constraint with an exact size.
+	fixedKind
+
+	// percentKind is a constraint whose size is a percentage of its parent.
+	percentKind
+)
+
+// Constraint specifies how much space a node takes along the direction of its
+// parent's split. The zero value is the same as Fill.
+type Constraint struct {
+	kind  constraintKind
+	value int
+
+	// min is the minimum size of the node.
+	min int
+
+	// max is the maximum size of the node. 0 means unbounded.
+	max int
+}
+
+// Fixed creates a constraint with an exact size.
+//
+// Parameters:
+//   - size: The size of the node.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the size is less than 0.
+func Fixed(size int) (Constraint, error) {
+	if size < 0 {
+		return Constraint{}, errors.NewErrInvalidParameter("size", errors.NewErrGTE(0))
+	}
+
+	return Constraint{
+		kind:  fixedKind,
+		value: size,
+	}, nil
+}
+
+// Percent creates a constraint whose size is a percentage of the size of its
+// parent. The result is rounded down.
+//
+// Parameters:
+//   - percent: The percentage, between 0 and 100.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the percentage is not in [0, 100].
+func Percent(percent int) (Constraint, error) {
+	if percent < 0 {
+		return Constraint{}, errors.NewErrInvalidParameter("percent", errors.NewErrGTE(0))
+	} else if percent > 100 {
+		return Constraint{}, errors.NewErrInvalidParameter("percent", errors.NewErrLTE(100))
+	}
+
+	return Constraint{
+		kind:  percentKind,
+		value: percent,
+	}, nil
+}
+
+// Flex creates a constraint that shares the space left by its non-flexible siblings
+// with the other flexible siblings, in proportion to their ratios.
+//
+// Parameters:
+//   - ratio: The ratio of the node.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the ratio is less than 1.
+func Flex(ratio int) (Constraint, error) {
+	if ratio < 1 {
+		return Constraint{}, errors.NewErrInvalidParameter("ratio", errors.NewErrGTE(1))
+	}
+
+	return Constraint{
+		kind:  flexKind,
+		value: ratio,
+	}, nil
+}
+
+// Fill is a flexible constraint with a ratio of 1. It is the constraint of
+// nodes created without one.
+//
+// Returns:
+//   - Constraint: The new constraint.
+func Fill() Constraint {
+	return Constraint{
+		kind:  flexKind,
+		value: 1,
+	}
+}
+
+// Min creates a flexible constraint with a ratio of 1 and the given minimum size.
+//
+// Parameters:
+//   - size: The minimum size of the node.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the size is less than 0.
+func Min(size int) (Constraint, error) {
+	return Fill().WithMin(size)
+}
+
+// Max creates a flexible constraint with a ratio of 1 and the given maximum size.
+//
+// Parameters:
+//   - size: The maximum size of the node.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the size is less than 1.
+func Max(size int) (Constraint, error) {
+	return Fill().WithMax(size)
+}
+
+// WithMin returns a copy of the constraint with the given minimum size.
+//
+// Parameters:
+//   - size: The minimum size of the node.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the size is less than 0 or greater than the maximum.
+func (c Constraint) WithMin(size int) (Constraint, error) {
+	if size < 0 {
+		return c, errors.NewErrInvalidParameter("size", errors.NewErrGTE(0))
+	} else if c.max != 0 && size > c.max {
+		return c, errors.NewErrInvalidParameter("size", errors.NewErrLTE(c.max))
+	}
+
+	c.min = size
+
+	return c, nil
+}
+
+// WithMax returns a copy of the constraint with the given maximum size.
+//
+// Parameters:
+//   - size: The maximum size of the node.
+//
+// Returns:
+//   - Constraint: The new constraint.
+//   - error: An error if the constraint could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the size is less than 1 or less than the minimum.
+func (c Constraint) WithMax(size int) (Constraint, error) {
+	if size < 1 {
+		return c, errors.NewErrInvalidParameter("size", errors.NewErrGTE(1))
+	} else if size < c.min {
+		return c, errors.NewErrInvalidParameter("size", errors.NewErrGTE(c.min))
+	}
+
+	c.max = size
+
+	return c, nil
+}
+
+// ratio returns the ratio of a flexible constraint. The zero value of Constraint
+// has a ratio of 1, like Fill.
+//
+// Returns:
+//   - int: The ratio. Always at least 1.
+func (c Constraint) ratio() int {
+	return max(c.value, 1)
+}
+
+// clamp clamps the given size to the minimum and maximum of the constraint.
+//
+// Parameters:
+//   - size: The size to clamp.
+//
+// Returns:
+//   - int: The clamped size.
+func (c Constraint) clamp(size int) int {
+	if c.max != 0 && size > c.max {
+		size = c.max
+	}
+
+	if size < c.min {
+		size = c.min
+	}
+
+	return size
+}
@@ -0,0 +1,291 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table/geom"
+)
+
+// Direction is the direction along which a node splits its rectangle.
+type Direction int
+
+const (
+	// Horizontal places the children of a node side by side, from left to right.
+	Horizontal Direction = iota
+
+	// Vertical stacks the children of a node, from top to bottom.
+	Vertical
+)
+
+// String implements the fmt.Stringer interface.
+func (d Direction) String() string {
+	switch d {
+	case Horizontal:
+		return "horizontal"
+	case Vertical:
+		return "vertical"
+	default:
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+}
+
+// Node is a node of a layout tree. A node without children is a pane; otherwise
+// it is split, along its direction, between its children.
+type Node struct {
+	name       string
+	constraint Constraint
+	direction  Direction
+	children   []*Node
+}
+
+// NewPane creates a new leaf node.
+//
+// Parameters:
+//   - name: The name of the pane. Unnamed nodes are not reported by Resolve.
+//   - constraint: The size of the pane along the direction of its parent.
+//
+// Returns:
+//   - *Node: The new node. Never returns nil.
+func NewPane(name string, constraint Constraint) *Node {
+	return &Node{
+		name:       name,
+		constraint: constraint,
+	}
+}
+
+// NewSplit creates a new node that splits its rectangle between its children.
+//
+// Parameters:
+//   - name: The name of the node. Unnamed nodes are not reported by Resolve.
+//   - constraint: The size of the node along the direction of its parent.
+//   - direction: The direction along which the children are placed.
+//   - children: The children of the node.
+//
+// Returns:
+//   - *Node: The new node.
+//   - error: An error if the node could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If any of the children is nil.
+func NewSplit(name string, constraint Constraint, direction Direction, children ...*Node) (*Node, error) {
+	for i, child := range children {
+		if child == nil {
+			return nil, errors.NewErrNilParameter(fmt.Sprintf("children[%d]", i))
+		}
+	}
+
+	return &Node{
+		name:       name,
+		constraint: constraint,
+		direction:  direction,
+		children:   children,
+	}, nil
+}
+
+// Name returns the name of the node.
+//
+// Returns:
+//   - string: The name of the node.
+func (n Node) Name() string {
+	return n.name
+}
+
+// Resolve computes the rectangle of every named node of the tree rooted at this
+// node. The root takes the whole given rectangle, regardless of its constraint.
+//
+// Since the layout is recomputed from scratch, resizing is handled by calling
+// Resolve again with the new root rectangle.
+//
+// Parameters:
+//   - root: The rectangle of the root node.
+//
+// Returns:
+//   - map[string]geom.Rect: The rectangles of the named nodes. Never nil.
+//   - error: An error if the layout could not be resolved.
+//
+// Errors:
+//   - errors.NilReceiver: If the node is nil.
+//   - error: If two nodes have the same name.
+//
+// When the constraints of the children of a node cannot be satisfied, the
+// children are shrunk starting from the last one so that they fit.
+func (n *Node) Resolve(root geom.Rect) (map[string]geom.Rect, error) {
+	if n == nil {
+		return nil, errors.NilReceiver
+	}
+
+	rects := make(map[string]geom.Rect)
+
+	err := n.resolve(geom.NewRect(root.X, root.Y, root.Width, root.Height), rects)
+	if err != nil {
+		return nil, err
+	}
+
+	return rects, nil
+}
+
+// resolve is a helper function that resolves the layout of the node into the
+// given map.
+//
+// Parameters:
+//   - rect: The rectangle of the node.
+//   - rects: The rectangles of the nodes resolved so far.
+//
+// Returns:
+//   - error: An error if two nodes have the same name.
+func (n Node) resolve(rect geom.Rect, rects map[string]geom.Rect) error {
+	if n.name != "" {
+		_, ok := rects[n.name]
+		if ok {
+			return fmt.Errorf("node %q is defined more than once", n.name)
+		}
+
+		rects[n.name] = rect
+	}
+
+	if len(n.children) == 0 {
+		return nil
+	}
+
+	constraints := make([]Constraint, 0, len(n.children))
+	for _, child := range n.children {
+		constraints = append(constraints, child.constraint)
+	}
+
+	var total int
+
+	if n.direction == Vertical {
+		total = rect.Height
+	} else {
+		total = rect.Width
+	}
+
+	sizes := distribute(constraints, total)
+
+	offset := 0
+
+	for i, child := range n.children {
+		var child_rect geom.Rect
+
+		if n.direction == Vertical {
+			child_rect = geom.NewRect(rect.X, rect.Y+offset, rect.Width, sizes[i])
+		} else {
+			child_rect = geom.NewRect(rect.X+offset, rect.Y, sizes[i], rect.Height)
+		}
+
+		err := child.resolve(child_rect, rects)
+		if err != nil {
+			return err
+		}
+
+		offset += sizes[i]
+	}
+
+	return nil
+}
+
+// distribute splits the given space between the given constraints.
+//
+// Parameters:
+//   - constraints: The constraints to satisfy.
+//   - total: The space to split.
+//
+// Returns:
+//   - []int: The size of each constraint. Their sum never exceeds total.
+func distribute(constraints []Constraint, total int) []int {
+	sizes := make([]int, len(constraints))
+	done := make([]bool, len(constraints))
+	remaining := total
+
+	for i, c := range constraints {
+		switch c.kind {
+		case fixedKind:
+			sizes[i] = c.clamp(c.value)
+		case percentKind:
+			sizes[i] = c.clamp(total * c.value / 100)
+		default:
+			continue
+		}
+
+		done[i] = true
+		remaining -= sizes[i]
+	}
+
+	// Flexible constraints that violate their bounds are frozen at the bound and
+	// the space is shared again between the others, until no bound is violated.
+	for {
+		var ratios int
+
+		for i, c := range constraints {
+			if !done[i] {
+				ratios += c.ratio()
+			}
+		}
+
+		if ratios == 0 {
+			break
+		}
+
+		space := max(remaining, 0)
+		shares := make([]int, len(constraints))
+		given := 0
+
+		for i, c := range constraints {
+			if !done[i] {
+				shares[i] = space * c.ratio() / ratios
+				given += shares[i]
+			}
+		}
+
+		// Hand out the rounding leftovers one cell at a time, in order.
+		for i := 0; given < space; i++ {
+			if !done[i%len(constraints)] {
+				shares[i%len(constraints)]++
+				given++
+			}
+		}
+
+		violated := false
+
+		for i, c := range constraints {
+			if done[i] {
+				continue
+			}
+
+			clamped := c.clamp(shares[i])
+			if clamped != shares[i] {
+				sizes[i] = clamped
+				done[i] = true
+				remaining -= clamped
+				violated = true
+			}
+		}
+
+		if violated {
+			continue
+		}
+
+		for i := range constraints {
+			if !done[i] {
+				sizes[i] = shares[i]
+			}
+		}
+
+		break
+	}
+
+	overflow := -max(total, 0)
+	for _, size := range sizes {
+		overflow += size
+	}
+
+	for i := len(sizes) - 1; i >= 0 && overflow > 0; i-- {
+		cut := min(sizes[i], overflow)
+
+		sizes[i] -= cut
+		overflow -= cut
+	}
+
+	return sizes
+}
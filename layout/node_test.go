@@ -0,0 +1,94 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table/geom"
+)
+
+// must returns c and panics if err is not nil.
+func must(c Constraint, err error) Constraint {
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func TestDistribute(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints []Constraint
+		total       int
+		want        []int
+	}{
+		{"fills", []Constraint{Fill(), Fill()}, 10, []int{5, 5}},
+		{"rounding", []Constraint{Fill(), Fill(), Fill()}, 10, []int{4, 3, 3}},
+		{"ratios", []Constraint{must(Flex(1)), must(Flex(3))}, 8, []int{2, 6}},
+		{"mixed", []Constraint{must(Fixed(3)), must(Percent(50)), Fill()}, 10, []int{3, 5, 2}},
+		{"min", []Constraint{must(Min(6)), Fill()}, 8, []int{6, 2}},
+		{"max", []Constraint{must(Max(2)), Fill()}, 10, []int{2, 8}},
+		{"clamped fixed", []Constraint{must(must(Fixed(5)).WithMax(3)), Fill()}, 10, []int{3, 7}},
+		{"clamped percent", []Constraint{must(must(Percent(10)).WithMin(4)), Fill()}, 10, []int{4, 6}},
+		{"overflowing fixed", []Constraint{must(Fixed(6)), must(Fixed(6))}, 10, []int{6, 4}},
+		{"overflowing min", []Constraint{must(Fixed(4)), must(Min(5)), must(Fixed(4))}, 10, []int{4, 5, 1}},
+		{"no space", []Constraint{must(Fixed(3)), Fill()}, 0, []int{0, 0}},
+		{"zero value", []Constraint{{}, Fill()}, 10, []int{5, 5}},
+		{"zero value with min", []Constraint{must(Constraint{}.WithMin(4)), must(Flex(3))}, 8, []int{4, 4}},
+		{"only zero values", []Constraint{{}, {}}, 3, []int{2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := distribute(tt.constraints, tt.total)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	sidebar := NewPane("sidebar", must(Fixed(4)))
+	header := NewPane("header", must(Fixed(1)))
+	body := NewPane("body", Fill())
+
+	main, err := NewSplit("", Fill(), Vertical, header, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := NewSplit("root", Fill(), Horizontal, sidebar, main)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := root.Resolve(geom.NewRect(1, 1, 10, 5))
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+
+	want := map[string]geom.Rect{
+		"root":    geom.NewRect(1, 1, 10, 5),
+		"sidebar": geom.NewRect(1, 1, 4, 5),
+		"header":  geom.NewRect(5, 1, 6, 1),
+		"body":    geom.NewRect(5, 2, 6, 4),
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestResolveRejectsDuplicateNames(t *testing.T) {
+	root, err := NewSplit("root", Fill(), Horizontal, NewPane("a", Fill()), NewPane("a", Fill()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = root.Resolve(geom.NewRect(0, 0, 2, 1))
+	if err == nil {
+		t.Error("got nil, want an error")
+	}
+}
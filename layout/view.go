@@ -0,0 +1,128 @@
+package layout
+
+import (
+	"iter"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// View is a rectangular region of a table. Coordinates given to a view are
+// relative to the top-left cell of its rectangle and writes never leave it.
+type View[T any] struct {
+	dst  table.ReadWriter[T]
+	rect geom.Rect
+}
+
+// _ asserts that every instance of View implements the table.ReadWriter interface.
+func _[T any]() {
+	var _ table.ReadWriter[T] = (*View[T])(nil)
+}
+
+// NewView creates a new view over the given region of a table.
+//
+// Parameters:
+//   - dst: The table the view reads from and writes to.
+//   - rect: The region of the table.
+//
+// Returns:
+//   - *View[T]: The new view.
+//   - error: An error if the view could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If dst is nil.
+func NewView[T any](dst table.ReadWriter[T], rect geom.Rect) (*View[T], error) {
+	if dst == nil {
+		return nil, errors.NewErrNilParameter("dst")
+	}
+
+	return &View[T]{
+		dst:  dst,
+		rect: geom.NewRect(rect.X, rect.Y, rect.Width, rect.Height),
+	}, nil
+}
+
+// Rect returns the region of the table covered by the view.
+//
+// Returns:
+//   - geom.Rect: The region of the view.
+func (v View[T]) Rect() geom.Rect {
+	return v.rect
+}
+
+// Width implements the table.Reader interface.
+func (v View[T]) Width() int {
+	return v.rect.Width
+}
+
+// Height implements the table.Reader interface.
+func (v View[T]) Height() int {
+	return v.rect.Height
+}
+
+// CellAt implements the table.Reader interface.
+func (v View[T]) CellAt(x, y int) T {
+	if x < 0 || x >= v.rect.Width || y < 0 || y >= v.rect.Height {
+		return *new(T)
+	}
+
+	return v.dst.CellAt(v.rect.X+x, v.rect.Y+y)
+}
+
+// Cell implements the table.Reader interface.
+func (v View[T]) Cell() iter.Seq[T] {
+	fn := func(yield func(T) bool) {
+		for y := 0; y < v.rect.Height; y++ {
+			for x := 0; x < v.rect.Width; x++ {
+				if !yield(v.dst.CellAt(v.rect.X+x, v.rect.Y+y)) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Row implements the table.Reader interface.
+//
+// The yielded slice is reused between rows.
+func (v View[T]) Row() iter.Seq[[]T] {
+	fn := func(yield func([]T) bool) {
+		row := make([]T, v.rect.Width)
+
+		for y := 0; y < v.rect.Height; y++ {
+			for x := range row {
+				row[x] = v.dst.CellAt(v.rect.X+x, v.rect.Y+y)
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// WriteAt implements the table.Writer interface.
+func (v View[T]) WriteAt(x, y int, cell T) {
+	if x < 0 || x >= v.rect.Width || y < 0 || y >= v.rect.Height {
+		return
+	}
+
+	v.dst.WriteAt(v.rect.X+x, v.rect.Y+y, cell)
+}
+
+// Fill sets every cell of the view to the given value.
+//
+// Parameters:
+//   - cell: The value to write.
+func (v View[T]) Fill(cell T) {
+	for y := 0; y < v.rect.Height; y++ {
+		for x := 0; x < v.rect.Width; x++ {
+			v.dst.WriteAt(v.rect.X+x, v.rect.Y+y, cell)
+		}
+	}
+}
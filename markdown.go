@@ -0,0 +1,260 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+var (
+	// markdownEscaper escapes the content of a cell of a Markdown table. Backslashes
+	// are escaped as well, so that a cell ending with one does not escape the pipe
+	// that follows it.
+	markdownEscaper = strings.NewReplacer("\\", "\\\\", "|", "\\|", "\r\n", "<br>", "\n", "<br>")
+
+	// markdownBreak matches the line breaks of a cell of a Markdown table.
+	markdownBreak = regexp.MustCompile(`(?i)<br\s*/?>`)
+)
+
+// RenderMarkdown prints the table as a GitHub Flavored Markdown pipe table whose
+// header is the first row of the table. It is a shorthand for Render with
+// BorderMarkdown.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - align: The alignment of each column, printed as the alignment markers of
+//     the delimiter row. Columns without an alignment are left aligned.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (t StringTable) RenderMarkdown(w io.Writer, align ...Alignment) error {
+	return t.Render(w, markdownOptions(align))
+}
+
+// RenderMarkdown prints the table as a GitHub Flavored Markdown pipe table, like
+// StringTable.RenderMarkdown does. Since Markdown tables cannot merge cells, the
+// content of a span is printed in its origin and its covered cells are empty.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - align: The alignment of each column.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (m MergedTable) RenderMarkdown(w io.Writer, align ...Alignment) error {
+	return m.Render(w, markdownOptions(align))
+}
+
+// markdownOptions returns the rendering options of a Markdown table.
+//
+// Parameters:
+//   - align: The alignment of each column.
+//
+// Returns:
+//   - RenderOptions: The rendering options.
+func markdownOptions(align []Alignment) RenderOptions {
+	opts := RenderOptions{
+		Border:  BorderMarkdown,
+		Header:  true,
+		Columns: make([]ColumnOptions, 0, len(align)),
+	}
+
+	for _, a := range align {
+		opts.Columns = append(opts.Columns, ColumnOptions{Align: a})
+	}
+
+	return opts
+}
+
+// ReadMarkdown reads a GitHub Flavored Markdown pipe table into a string table.
+// The header of the Markdown table becomes the first row of the table. Blank
+// lines before the table are skipped and the table ends at the first blank line
+// or at the end of the input.
+//
+// As in GitHub Flavored Markdown, rows with fewer cells than the header are padded
+// with empty cells and the excess cells of longer rows are ignored. Escaped pipes
+// and backslashes are unescaped and <br> elements become newlines.
+//
+// Parameters:
+//   - r: The reader to read from.
+//
+// Returns:
+//   - *StringTable: The cells of the table.
+//   - []Alignment: The alignment of each column, as given by the delimiter row.
+//   - error: An error if the table could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the input is not a pipe table or any error returned by r.
+func ReadMarkdown(r io.Reader) (*StringTable, []Alignment, error) {
+	if r == nil {
+		return nil, nil, errors.NewErrNilParameter("r")
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	var rows [][]string
+	var align []Alignment
+
+	var line_no int
+
+	for scanner.Scan() {
+		line_no++
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" {
+			if len(rows) == 0 {
+				continue
+			}
+
+			break
+		}
+
+		cells := splitMarkdownRow(line)
+
+		switch {
+		case len(rows) == 0:
+			if !strings.Contains(line, "|") {
+				return nil, nil, fmt.Errorf("line %d: header row %q has no pipe", line_no, line)
+			}
+
+			rows = append(rows, cells)
+			continue
+		case align == nil:
+			var err error
+
+			align, err = parseMarkdownDelimiter(cells)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: %w", line_no, err)
+			} else if len(align) != len(rows[0]) {
+				return nil, nil, fmt.Errorf("line %d: delimiter row has %d cells but the header has %d", line_no, len(align), len(rows[0]))
+			}
+
+			continue
+		}
+
+		rows = append(rows, cells)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil, fmt.Errorf("no table found")
+	} else if align == nil {
+		return nil, nil, fmt.Errorf("line %d: missing delimiter row", line_no+1)
+	}
+
+	table, err := NewStringTable(len(align), len(rows))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for y, row := range rows {
+		for x, cell := range row[:min(len(row), len(align))] {
+			table.table[y][x] = cell
+		}
+	}
+
+	return table, align, nil
+}
+
+// splitMarkdownRow splits a row of a Markdown table into its cells.
+//
+// Parameters:
+//   - line: The row, without leading and trailing spaces.
+//
+// Returns:
+//   - []string: The unescaped cells of the row.
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+
+	var cells []string
+	var cell strings.Builder
+
+	// closed tells whether the last cell was ended by a pipe; that is, whether
+	// the row has a trailing pipe.
+	var closed bool
+
+	for i := 0; i < len(line); i++ {
+		closed = false
+
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && (line[i+1] == '|' || line[i+1] == '\\'):
+			cell.WriteByte(line[i+1])
+			i++
+		case line[i] == '|':
+			cells = append(cells, unescapeMarkdownCell(cell.String()))
+			cell.Reset()
+			closed = true
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	if closed {
+		return cells
+	}
+
+	return append(cells, unescapeMarkdownCell(cell.String()))
+}
+
+// unescapeMarkdownCell trims a cell of a Markdown table and turns its <br>
+// elements into newlines.
+//
+// Parameters:
+//   - cell: The cell, with its pipes and backslashes already unescaped.
+//
+// Returns:
+//   - string: The content of the cell.
+func unescapeMarkdownCell(cell string) string {
+	return markdownBreak.ReplaceAllString(strings.TrimSpace(cell), "\n")
+}
+
+// parseMarkdownDelimiter parses the delimiter row of a Markdown table.
+//
+// Parameters:
+//   - cells: The cells of the delimiter row.
+//
+// Returns:
+//   - []Alignment: The alignment of each column.
+//   - error: An error if a cell is not a valid delimiter.
+func parseMarkdownDelimiter(cells []string) ([]Alignment, error) {
+	align := make([]Alignment, 0, len(cells))
+
+	for i, cell := range cells {
+		left := strings.HasPrefix(cell, ":")
+		right := strings.HasSuffix(cell, ":")
+
+		dashes := strings.TrimSuffix(strings.TrimPrefix(cell, ":"), ":")
+		if dashes == "" || strings.Trim(dashes, "-") != "" {
+			return nil, fmt.Errorf("cell %d of the delimiter row (%q) is not a delimiter", i+1, cell)
+		}
+
+		switch {
+		case left && right:
+			align = append(align, AlignCenter)
+		case right:
+			align = append(align, AlignRight)
+		default:
+			align = append(align, AlignLeft)
+		}
+	}
+
+	return align, nil
+}
@@ -0,0 +1,78 @@
+package table_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		rows  [][]string
+		align []table.Alignment
+	}{
+		{"plain", [][]string{{"name", "count"}, {"a", "1"}}, []table.Alignment{table.AlignLeft, table.AlignRight}},
+		{"pipes", [][]string{{"a|b", "c"}, {"|", "||"}}, nil},
+		{"backslashes", [][]string{{`C:\`, `\|`}, {`\\`, `x\`}}, nil},
+		{"newlines", [][]string{{"a\nb", "c"}, {"d", "e\r\nf"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := must(table.NewStringTableFromRows(tt.rows))
+
+			var buf bytes.Buffer
+
+			err := src.RenderMarkdown(&buf, tt.align...)
+			if err != nil {
+				t.Fatalf("RenderMarkdown: %v", err)
+			}
+
+			got, align, err := table.ReadMarkdown(strings.NewReader(buf.String()))
+			if err != nil {
+				t.Fatalf("ReadMarkdown(%q): %v", buf.String(), err)
+			}
+
+			want := src.FullTable()
+
+			// Line breaks are read back as "\n".
+			for _, row := range want {
+				for x, cell := range row {
+					row[x] = strings.ReplaceAll(cell, "\r\n", "\n")
+				}
+			}
+
+			if !reflect.DeepEqual(got.FullTable(), want) {
+				t.Errorf("got %q, want %q (rendered as %q)", got.FullTable(), want, buf.String())
+			}
+
+			if tt.align != nil && !reflect.DeepEqual(align, tt.align) {
+				t.Errorf("got alignments %v, want %v", align, tt.align)
+			}
+		})
+	}
+}
+
+func TestReadMarkdownEscapes(t *testing.T) {
+	input := strings.Join([]string{
+		`| a | b |`,
+		`| --- | --- |`,
+		`| x\\ | \| |`,
+		`| y\\|z`,
+	}, "\n")
+
+	got, _, err := table.ReadMarkdown(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadMarkdown: %v", err)
+	}
+
+	want := [][]string{{"a", "b"}, {`x\`, "|"}, {`y\`, "z"}}
+
+	if !reflect.DeepEqual(got.FullTable(), want) {
+		t.Errorf("got %q, want %q", got.FullTable(), want)
+	}
+}
@@ -0,0 +1,649 @@
+package table
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// NetpbmFormat is a format of the Netpbm family. Its value is the digit of the
+// magic number of the format.
+type NetpbmFormat int
+
+const (
+	// PlainPBM is the ASCII bitmap format (P1).
+	PlainPBM NetpbmFormat = iota + 1
+
+	// PlainPGM is the ASCII graymap format (P2).
+	PlainPGM
+
+	// PlainPPM is the ASCII pixmap format (P3).
+	PlainPPM
+
+	// RawPBM is the binary bitmap format (P4).
+	RawPBM
+
+	// RawPGM is the binary graymap format (P5).
+	RawPGM
+
+	// RawPPM is the binary pixmap format (P6).
+	RawPPM
+)
+
+// String implements the fmt.Stringer interface.
+//
+// Format: "P<digit>"
+func (f NetpbmFormat) String() string {
+	if f < PlainPBM || f > RawPPM {
+		return fmt.Sprintf("NetpbmFormat(%d)", int(f))
+	}
+
+	return "P" + strconv.Itoa(int(f))
+}
+
+// isPlain checks whether the format is an ASCII one.
+//
+// Returns:
+//   - bool: True if the format is an ASCII one, false otherwise.
+func (f NetpbmFormat) isPlain() bool {
+	return f <= PlainPPM
+}
+
+// isBitmap checks whether the format is a PBM one.
+//
+// Returns:
+//   - bool: True if the format is a PBM one, false otherwise.
+func (f NetpbmFormat) isBitmap() bool {
+	return f == PlainPBM || f == RawPBM
+}
+
+// isPixmap checks whether the format is a PPM one.
+//
+// Returns:
+//   - bool: True if the format is a PPM one, false otherwise.
+func (f NetpbmFormat) isPixmap() bool {
+	return f == PlainPPM || f == RawPPM
+}
+
+// maxRasterPixels is the maximum number of pixels of a decoded image, the same as
+// the maximum number of cells of the other decoders of the package. It protects the
+// decoders against corrupted sizes.
+const maxRasterPixels = 1 << 24
+
+// rasterChunk is the number of pixels a raster is created with room for. The
+// decoders append the pixels as they are read so that a header that claims more
+// pixels than the input holds does not allocate them.
+const rasterChunk = 1 << 16
+
+// raster is a grayscale image, as written to or read from an image file.
+type raster struct {
+	width, height int
+
+	// maxval is the value of white. Black is 0.
+	maxval int
+
+	// pix are the gray levels of the pixels, row by row.
+	pix []uint16
+}
+
+// newRaster creates a new raster without pixels, checking its size. The pixels are
+// to be appended to pix, row by row.
+//
+// Parameters:
+//   - width: The width of the raster.
+//   - height: The height of the raster.
+//   - maxval: The value of white.
+//
+// Returns:
+//   - raster: The new raster.
+//   - error: An error if the raster has more than maxRasterPixels pixels.
+func newRaster(width, height, maxval int) (raster, error) {
+	err := checkRasterSize(width, height)
+	if err != nil {
+		return raster{}, err
+	}
+
+	return raster{
+		width:  width,
+		height: height,
+		maxval: maxval,
+		pix:    make([]uint16, 0, min(width*height, rasterChunk)),
+	}, nil
+}
+
+// checkRasterSize checks that an image of the given size does not exceed
+// maxRasterPixels. Rows count as at least one pixel so that images without columns
+// are capped as well.
+//
+// Parameters:
+//   - width: The width of the image.
+//   - height: The height of the image.
+//
+// Returns:
+//   - error: An error if the image has more than maxRasterPixels pixels.
+func checkRasterSize(width, height int) error {
+	if width > maxRasterPixels || height > maxRasterPixels || max(width, 1)*max(height, 1) > maxRasterPixels {
+		return fmt.Errorf("image of %dx%d pixels exceeds the limit of %d pixels", width, height, maxRasterPixels)
+	}
+
+	return nil
+}
+
+// scale returns the gray level of a pixel for the given value of white, rounded
+// to the nearest level.
+//
+// Parameters:
+//   - idx: The index of the pixel.
+//   - maxval: The value of white.
+//
+// Returns:
+//   - int: The gray level.
+func (r raster) scale(idx, maxval int) int {
+	v := int(r.pix[idx])
+
+	if r.maxval == maxval {
+		return v
+	}
+
+	return (v*maxval + r.maxval/2) / r.maxval
+}
+
+// isBlack checks whether a pixel is darker than middle gray.
+//
+// Parameters:
+//   - idx: The index of the pixel.
+//
+// Returns:
+//   - bool: True if the pixel is dark, false otherwise.
+func (r raster) isBlack(idx int) bool {
+	return 2*int(r.pix[idx]) < r.maxval
+}
+
+// writeNetpbm writes a raster in the given Netpbm format. PBM formats write the
+// pixels darker than middle gray as black; PPM formats write gray pixels.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - format: The format of the image.
+//   - r: The raster to write.
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil or the format is unknown.
+//   - error: Any error returned by w.
+func writeNetpbm(w io.Writer, format NetpbmFormat, r raster) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	} else if format < PlainPBM || format > RawPPM {
+		return errors.NewErrInvalidParameter("format", fmt.Errorf("unknown format %v", format))
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "%v\n%d %d\n", format, r.width, r.height)
+
+	if !format.isBitmap() {
+		fmt.Fprintf(bw, "%d\n", r.maxval)
+	}
+
+	samples := 1
+	if format.isPixmap() {
+		samples = 3
+	}
+
+	if format.isPlain() {
+		pw := plainWriter{w: bw}
+
+		for y := 0; y < r.height; y++ {
+			for x := 0; x < r.width; x++ {
+				idx := y*r.width + x
+
+				if format.isBitmap() {
+					if r.isBlack(idx) {
+						pw.token("1")
+					} else {
+						pw.token("0")
+					}
+
+					continue
+				}
+
+				token := strconv.Itoa(int(r.pix[idx]))
+
+				for i := 0; i < samples; i++ {
+					pw.token(token)
+				}
+			}
+
+			pw.newline()
+		}
+
+		return bw.Flush()
+	}
+
+	if format.isBitmap() {
+		row := make([]byte, (r.width+7)/8)
+
+		for y := 0; y < r.height; y++ {
+			clear(row)
+
+			for x := 0; x < r.width; x++ {
+				if r.isBlack(y*r.width + x) {
+					row[x/8] |= 0x80 >> (x % 8)
+				}
+			}
+
+			bw.Write(row)
+		}
+
+		return bw.Flush()
+	}
+
+	var buf [2]byte
+
+	for _, v := range r.pix {
+		for i := 0; i < samples; i++ {
+			if r.maxval < 256 {
+				bw.WriteByte(byte(v))
+			} else {
+				binary.BigEndian.PutUint16(buf[:], v)
+				bw.Write(buf[:])
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// plainLineWidth is the maximum length of the lines of the ASCII formats.
+const plainLineWidth = 70
+
+// plainWriter writes the samples of an ASCII Netpbm image, separated by spaces, in
+// lines of at most plainLineWidth characters.
+type plainWriter struct {
+	w *bufio.Writer
+
+	// n is the length of the current line.
+	n int
+}
+
+// token writes a sample.
+//
+// Parameters:
+//   - s: The sample to write.
+func (pw *plainWriter) token(s string) {
+	if pw.n > 0 && pw.n+1+len(s) > plainLineWidth {
+		pw.newline()
+	}
+
+	if pw.n > 0 {
+		pw.w.WriteByte(' ')
+		pw.n++
+	}
+
+	pw.w.WriteString(s)
+	pw.n += len(s)
+}
+
+// newline ends the current line, if not empty.
+func (pw *plainWriter) newline() {
+	if pw.n == 0 {
+		return
+	}
+
+	pw.w.WriteByte('\n')
+	pw.n = 0
+}
+
+// readNetpbm reads an image in any of the Netpbm formats. Bitmaps have a value of
+// white of 1 and pixmaps are converted to grayscale with the ITU-R BT.601 luma
+// coefficients.
+//
+// Parameters:
+//   - r: The reader to read from.
+//
+// Returns:
+//   - raster: The image.
+//   - NetpbmFormat: The format of the image.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the image is malformed or any error returned by r.
+func readNetpbm(r io.Reader) (raster, NetpbmFormat, error) {
+	if r == nil {
+		return raster{}, 0, errors.NewErrNilParameter("r")
+	}
+
+	br := bufio.NewReader(r)
+
+	var magic [2]byte
+
+	_, err := io.ReadFull(br, magic[:])
+	if err != nil {
+		return raster{}, 0, fmt.Errorf("netpbm: %w", unexpectedEOF(err))
+	}
+
+	format := NetpbmFormat(magic[1] - '0')
+	if magic[0] != 'P' || format < PlainPBM || format > RawPPM {
+		return raster{}, 0, fmt.Errorf("netpbm: unknown magic number %q", magic[:])
+	}
+
+	header := []int{0, 0, 1}
+
+	count := 3
+	if format.isBitmap() {
+		count = 2
+	}
+
+	for i := 0; i < count; i++ {
+		header[i], err = readNetpbmInt(br)
+		if err != nil {
+			return raster{}, 0, err
+		}
+	}
+
+	maxval := header[2]
+	if maxval < 1 || maxval > math.MaxUint16 {
+		return raster{}, 0, fmt.Errorf("netpbm: invalid maximum value %d", maxval)
+	}
+
+	img, err := newRaster(header[0], header[1], maxval)
+	if err != nil {
+		return raster{}, 0, fmt.Errorf("netpbm: %w", err)
+	}
+
+	switch {
+	case format == PlainPBM:
+		err = readPlainBitmap(br, &img)
+	case format == RawPBM:
+		err = readRawBitmap(br, &img)
+	case format.isPlain():
+		err = readPlainSamples(br, &img, format.isPixmap())
+	default:
+		err = readRawSamples(br, &img, format.isPixmap())
+	}
+
+	if err != nil {
+		return raster{}, 0, err
+	}
+
+	return img, format, nil
+}
+
+// readNetpbmInt reads a decimal number of the header, skipping the whitespace and
+// comments before it. For the last number of the header, the single whitespace
+// that follows it is consumed as well.
+//
+// Parameters:
+//   - br: The reader to read from.
+//
+// Returns:
+//   - int: The number.
+//   - error: An error if no number could be read.
+func readNetpbmInt(br *bufio.Reader) (int, error) {
+	err := skipNetpbmSpace(br)
+	if err != nil {
+		return 0, err
+	}
+
+	var n, digits int
+
+	for {
+		c, err := br.ReadByte()
+		if err == io.EOF && digits > 0 {
+			break
+		} else if err != nil {
+			return 0, fmt.Errorf("netpbm: %w", unexpectedEOF(err))
+		}
+
+		if c < '0' || c > '9' {
+			if digits == 0 {
+				return 0, fmt.Errorf("netpbm: unexpected byte %q", c)
+			}
+
+			switch c {
+			case ' ', '\t', '\n', '\r', '\v', '\f':
+			case '#':
+				br.UnreadByte()
+			default:
+				return 0, fmt.Errorf("netpbm: unexpected byte %q after a number", c)
+			}
+
+			break
+		}
+
+		n = n*10 + int(c-'0')
+		digits++
+
+		if n > maxRasterPixels {
+			return 0, fmt.Errorf("netpbm: number is too large")
+		}
+	}
+
+	return n, nil
+}
+
+// skipNetpbmSpace skips whitespace and comments.
+//
+// Parameters:
+//   - br: The reader to read from.
+//
+// Returns:
+//   - error: An error if the end of the input is reached.
+func skipNetpbmSpace(br *bufio.Reader) error {
+	for {
+		c, err := br.ReadByte()
+		if err != nil {
+			return fmt.Errorf("netpbm: %w", unexpectedEOF(err))
+		}
+
+		switch c {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+		case '#':
+			_, err := br.ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("netpbm: %w", unexpectedEOF(err))
+			}
+		default:
+			return br.UnreadByte()
+		}
+	}
+}
+
+// readPlainBitmap reads the pixels of a P1 image. Pixels need not be separated by
+// whitespace.
+//
+// Parameters:
+//   - br: The reader to read from.
+//   - img: The raster to append the pixels to.
+//
+// Returns:
+//   - error: An error if the pixels could not be read.
+func readPlainBitmap(br *bufio.Reader, img *raster) error {
+	for range img.width * img.height {
+		err := skipNetpbmSpace(br)
+		if err != nil {
+			return err
+		}
+
+		c, _ := br.ReadByte()
+
+		switch c {
+		case '0':
+			img.pix = append(img.pix, 1)
+		case '1':
+			img.pix = append(img.pix, 0)
+		default:
+			return fmt.Errorf("netpbm: unexpected byte %q in bitmap", c)
+		}
+	}
+
+	return nil
+}
+
+// readRawBitmap reads the pixels of a P4 image.
+//
+// Parameters:
+//   - br: The reader to read from.
+//   - img: The raster to append the pixels to.
+//
+// Returns:
+//   - error: An error if the pixels could not be read.
+func readRawBitmap(br *bufio.Reader, img *raster) error {
+	row := make([]byte, (img.width+7)/8)
+
+	for y := 0; y < img.height; y++ {
+		_, err := io.ReadFull(br, row)
+		if err != nil {
+			return fmt.Errorf("netpbm: %w", unexpectedEOF(err))
+		}
+
+		for x := 0; x < img.width; x++ {
+			var v uint16
+
+			if row[x/8]&(0x80>>(x%8)) == 0 {
+				v = 1
+			}
+
+			img.pix = append(img.pix, v)
+		}
+	}
+
+	return nil
+}
+
+// readPlainSamples reads the pixels of a P2 or P3 image.
+//
+// Parameters:
+//   - br: The reader to read from.
+//   - img: The raster to append the pixels to.
+//   - rgb: Whether each pixel is made of three samples.
+//
+// Returns:
+//   - error: An error if the pixels could not be read.
+func readPlainSamples(br *bufio.Reader, img *raster, rgb bool) error {
+	next := func() (int, error) {
+		v, err := readNetpbmInt(br)
+		if err != nil {
+			return 0, err
+		} else if v > img.maxval {
+			return 0, fmt.Errorf("netpbm: sample %d exceeds the maximum value %d", v, img.maxval)
+		}
+
+		return v, nil
+	}
+
+	return readSamples(img, rgb, next)
+}
+
+// readRawSamples reads the pixels of a P5 or P6 image.
+//
+// Parameters:
+//   - br: The reader to read from.
+//   - img: The raster to append the pixels to.
+//   - rgb: Whether each pixel is made of three samples.
+//
+// Returns:
+//   - error: An error if the pixels could not be read.
+func readRawSamples(br *bufio.Reader, img *raster, rgb bool) error {
+	size := 1
+	if img.maxval > math.MaxUint8 {
+		size = 2
+	}
+
+	var buf [2]byte
+
+	next := func() (int, error) {
+		_, err := io.ReadFull(br, buf[:size])
+		if err != nil {
+			return 0, fmt.Errorf("netpbm: %w", unexpectedEOF(err))
+		}
+
+		v := int(buf[0])
+		if size == 2 {
+			v = int(binary.BigEndian.Uint16(buf[:]))
+		}
+
+		if v > img.maxval {
+			return 0, fmt.Errorf("netpbm: sample %d exceeds the maximum value %d", v, img.maxval)
+		}
+
+		return v, nil
+	}
+
+	return readSamples(img, rgb, next)
+}
+
+// readSamples appends the pixels made of the given samples to a raster.
+//
+// Parameters:
+//   - img: The raster to append the pixels to.
+//   - rgb: Whether each pixel is made of three samples.
+//   - next: The function that returns the next sample.
+//
+// Returns:
+//   - error: An error if a sample could not be read.
+func readSamples(img *raster, rgb bool, next func() (int, error)) error {
+	var rgb_samples [3]int
+
+	for range img.width * img.height {
+		if !rgb {
+			v, err := next()
+			if err != nil {
+				return err
+			}
+
+			img.pix = append(img.pix, uint16(v))
+			continue
+		}
+
+		for j := range rgb_samples {
+			v, err := next()
+			if err != nil {
+				return err
+			}
+
+			rgb_samples[j] = v
+		}
+
+		img.pix = append(img.pix, uint16(luma(rgb_samples[0], rgb_samples[1], rgb_samples[2])))
+	}
+
+	return nil
+}
+
+// luma returns the gray level of a color, with the ITU-R BT.601 coefficients.
+//
+// Parameters:
+//   - r: The red sample.
+//   - g: The green sample.
+//   - b: The blue sample.
+//
+// Returns:
+//   - int: The gray level, rounded to the nearest level.
+func luma(r, g, b int) int {
+	return (299*r + 587*g + 114*b + 500) / 1000
+}
+
+// unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF.
+//
+// Parameters:
+//   - err: The error to convert.
+//
+// Returns:
+//   - error: The converted error.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,162 @@
+package table_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestNetpbmRoundTrip(t *testing.T) {
+	src := must(table.NewUint8TableFromRows([][]uint8{{0, 128, 255}, {1, 2, 3}}))
+
+	for _, format := range []table.NetpbmFormat{table.PlainPGM, table.RawPGM, table.PlainPPM, table.RawPPM} {
+		t.Run(format.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := src.WriteNetpbm(&buf, format)
+			if err != nil {
+				t.Fatalf("WriteNetpbm: %v", err)
+			}
+
+			got, err := table.ReadUint8Netpbm(&buf)
+			if err != nil {
+				t.Fatalf("ReadUint8Netpbm: %v", err)
+			}
+
+			if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+				t.Errorf("got %v, want %v", got.FullTable(), src.FullTable())
+			}
+		})
+	}
+}
+
+func TestReadNetpbmRejectsBadSizes(t *testing.T) {
+	tests := map[string]string{
+		"huge image":      "P5 2147483647 2147483647 255\n",
+		"no columns":      "P5 0 2147483647 255\n",
+		"above the cap":   "P5 4097 4096 255\n",
+		"truncated raw":   "P5 4096 4096 255\n\x00\x01",
+		"truncated plain": "P2 4096 4096 255\n0 1 2",
+		"truncated bits":  "P4 4096 4096\n\x00",
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := table.ReadUint8Netpbm(strings.NewReader(data))
+			if err == nil {
+				t.Error("got nil, want an error")
+			}
+		})
+	}
+}
+
+func TestGrayPNGRoundTrip(t *testing.T) {
+	t.Run("BoolTable", func(t *testing.T) {
+		src := must(table.NewBoolTableFromRows([][]bool{{true, false}, {false, true}}))
+
+		var buf bytes.Buffer
+
+		err := src.WritePNG(&buf)
+		if err != nil {
+			t.Fatalf("WritePNG: %v", err)
+		}
+
+		got, err := table.ReadBoolPNG(&buf)
+		if err != nil {
+			t.Fatalf("ReadBoolPNG: %v", err)
+		}
+
+		if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+			t.Errorf("got %v, want %v", got.FullTable(), src.FullTable())
+		}
+	})
+	t.Run("Float64Table", func(t *testing.T) {
+		src := must(table.NewFloat64TableFromRows([][]float64{{0, 0.5}, {1, 2}}))
+		norm := table.Normalization{Min: 0, Max: 2}
+
+		var buf bytes.Buffer
+
+		err := src.WritePNG(&buf, norm)
+		if err != nil {
+			t.Fatalf("WritePNG: %v", err)
+		}
+
+		got, err := table.ReadFloat64PNG(&buf, norm)
+		if err != nil {
+			t.Fatalf("ReadFloat64PNG: %v", err)
+		}
+
+		want := src.FullTable()
+
+		for y, row := range got.FullTable() {
+			for x, cell := range row {
+				if d := cell - want[y][x]; d < -1e-4 || d > 1e-4 {
+					t.Errorf("cell (%d, %d) = %v, want %v", x, y, cell, want[y][x])
+				}
+			}
+		}
+	})
+}
+
+// pngHeader returns the beginning of a grayscale PNG image of the given size: its
+// signature, its IHDR chunk and an empty IDAT chunk.
+func pngHeader(width, height uint32) []byte {
+	chunk := func(dst []byte, name string, data []byte) []byte {
+		dst = binary.BigEndian.AppendUint32(dst, uint32(len(data)))
+		start := len(dst)
+		dst = append(dst, name...)
+		dst = append(dst, data...)
+
+		return binary.BigEndian.AppendUint32(dst, crc32.ChecksumIEEE(dst[start:]))
+	}
+
+	ihdr := binary.BigEndian.AppendUint32(nil, width)
+	ihdr = binary.BigEndian.AppendUint32(ihdr, height)
+	ihdr = append(ihdr, 8, 0, 0, 0, 0)
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = chunk(data, "IHDR", ihdr)
+
+	return chunk(data, "IDAT", nil)
+}
+
+func TestReadPNGRejectsBadSizes(t *testing.T) {
+	tests := map[string][2]uint32{
+		"huge image":    {1 << 20, 1 << 20},
+		"above the cap": {4097, 4096},
+	}
+
+	for name, size := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := table.ReadUint8PNG(bytes.NewReader(pngHeader(size[0], size[1])))
+			if err == nil || !strings.Contains(err.Error(), "exceeds the limit") {
+				t.Errorf("got %v, want a size error", err)
+			}
+		})
+	}
+}
+
+func TestByteTableNetpbm(t *testing.T) {
+	src := must(table.NewByteTableFromRows([][]byte{{0, 128, 255}}))
+
+	var buf bytes.Buffer
+
+	err := src.WriteNetpbm(&buf, table.RawPGM)
+	if err != nil {
+		t.Fatalf("WriteNetpbm: %v", err)
+	}
+
+	got, err := table.ReadByteNetpbm(&buf)
+	if err != nil {
+		t.Fatalf("ReadByteNetpbm: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+		t.Errorf("got %v, want %v", got.FullTable(), src.FullTable())
+	}
+}
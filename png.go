@@ -0,0 +1,112 @@
+package table
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// writePNG writes a raster as a grayscale PNG image. Rasters whose value of white
+// is at most 255 are written with 8 bits per pixel, the others with 16.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - r: The raster to write.
+//
+// Returns:
+//   - error: An error if the image could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w or by the PNG encoder.
+func writePNG(w io.Writer, r raster) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	}
+
+	rect := image.Rect(0, 0, r.width, r.height)
+
+	var img image.Image
+
+	if r.maxval <= math.MaxUint8 {
+		gray := image.NewGray(rect)
+
+		for i := range r.pix {
+			gray.Pix[i] = uint8(r.scale(i, math.MaxUint8))
+		}
+
+		img = gray
+	} else {
+		gray := image.NewGray16(rect)
+
+		for i := range r.pix {
+			v := r.scale(i, math.MaxUint16)
+
+			gray.Pix[2*i] = uint8(v >> 8)
+			gray.Pix[2*i+1] = uint8(v)
+		}
+
+		img = gray
+	}
+
+	return png.Encode(w, img)
+}
+
+// readPNG reads a PNG image as a 16-bit grayscale raster. Colors are converted to
+// gray as color.Gray16Model does; that is, with their alpha premultiplied.
+//
+// Parameters:
+//   - r: The reader to read from.
+//
+// Returns:
+//   - raster: The image.
+//   - error: An error if the image could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: Any error returned by the PNG decoder.
+func readPNG(r io.Reader) (raster, error) {
+	if r == nil {
+		return raster{}, errors.NewErrNilParameter("r")
+	}
+
+	// The size is checked before the image is decoded, since the decoder allocates
+	// the whole image.
+	var header bytes.Buffer
+
+	cfg, err := png.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return raster{}, err
+	}
+
+	err = checkRasterSize(cfg.Width, cfg.Height)
+	if err != nil {
+		return raster{}, err
+	}
+
+	img, err := png.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return raster{}, err
+	}
+
+	bounds := img.Bounds()
+
+	out, err := newRaster(bounds.Dx(), bounds.Dy(), math.MaxUint16)
+	if err != nil {
+		return raster{}, err
+	}
+
+	for y := 0; y < out.height; y++ {
+		for x := 0; x < out.width; x++ {
+			c := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			out.pix = append(out.pix, c.Y)
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,104 @@
+package record
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PlayerR9/table"
+)
+
+// screenRenderer turns a sequence of screens into the ANSI escape sequences that
+// draw them on a terminal. Only the rows that changed since the previous screen
+// are redrawn.
+type screenRenderer struct {
+	// prev are the runes drawn for the rows of the previous screen. Nil before the
+	// first one.
+	prev [][]rune
+
+	// width is the width of the previous screen.
+	width int
+}
+
+// render appends to a buffer the escape sequences that turn the previous screen
+// into the given one. The whole screen is redrawn the first time and whenever
+// its size changes. Cells are drawn as described by drawnRow.
+//
+// Parameters:
+//   - dst: The buffer to append to.
+//   - screen: The screen to draw.
+//
+// Returns:
+//   - []byte: The extended buffer.
+func (r *screenRenderer) render(dst []byte, screen table.Reader[rune]) []byte {
+	width, height := screen.Width(), screen.Height()
+
+	full := r.prev == nil || len(r.prev) != height || (height > 0 && r.width != width)
+	if full {
+		dst = append(dst, "\x1b[H\x1b[2J"...)
+	}
+
+	rows := make([][]rune, 0, height)
+
+	for y := 0; y < height; y++ {
+		row := drawnRow(screen, y, width)
+		rows = append(rows, row)
+
+		if !full && string(row) == string(r.prev[y]) {
+			continue
+		}
+
+		dst = append(dst, "\x1b["...)
+		dst = strconv.AppendInt(dst, int64(y+1), 10)
+		dst = append(dst, ";1H"...)
+		dst = append(dst, string(row)...)
+	}
+
+	r.prev = rows
+	r.width = width
+
+	return dst
+}
+
+// drawnRow returns the runes that draw a row of a screen so that every cell takes
+// exactly one column of the terminal:
+//   - zero cells, as well as zero-width runes such as combining marks, are drawn
+//     as spaces;
+//   - control runes, which the terminal would interpret, and invalid runes are
+//     drawn as U+FFFD;
+//   - wide runes cover the cell at their right, which is not drawn, or are drawn
+//     as spaces if they are in the last column.
+//
+// Parameters:
+//   - screen: The screen.
+//   - y: The index of the row.
+//   - width: The width of the screen.
+//
+// Returns:
+//   - []rune: The runes to draw.
+func drawnRow(screen table.Reader[rune], y, width int) []rune {
+	row := make([]rune, 0, width)
+
+	for x := 0; x < width; x++ {
+		cell := screen.CellAt(x, y)
+
+		if (cell != 0 && unicode.IsControl(cell)) || !utf8.ValidRune(cell) {
+			cell = utf8.RuneError
+		}
+
+		switch table.RuneWidth(cell) {
+		case 0:
+			cell = ' '
+		case 2:
+			if x+1 < width {
+				x++
+			} else {
+				cell = ' '
+			}
+		}
+
+		row = append(row, cell)
+	}
+
+	return row
+}
@@ -0,0 +1,125 @@
+package record
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table"
+)
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	// Version is the version of the format. Always 2.
+	Version int `json:"version"`
+
+	// Width is the number of columns of the terminal.
+	Width int `json:"width"`
+
+	// Height is the number of rows of the terminal.
+	Height int `json:"height"`
+}
+
+// CastRecorder records screens as an asciicast v2 file, which can be played by
+// asciinema or by Player.PlayCast. Each screen becomes an output event that
+// redraws the rows that changed since the previous one.
+//
+// Only the runes of the screens are recorded; styled screens, with colors or
+// attributes, are not supported.
+type CastRecorder struct {
+	w        io.Writer
+	renderer screenRenderer
+	start    time.Time
+	buff     []byte
+}
+
+// NewCastRecorder creates a new recorder and writes the header of the file.
+//
+// Parameters:
+//   - w: The writer to write the file to.
+//   - width: The number of columns of the terminal.
+//   - height: The number of rows of the terminal.
+//
+// Returns:
+//   - *CastRecorder: The new recorder.
+//   - error: An error if the recorder could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil or the width or height is less than 1.
+//   - error: Any error returned by w.
+func NewCastRecorder(w io.Writer, width, height int) (*CastRecorder, error) {
+	if w == nil {
+		return nil, errors.NewErrNilParameter("w")
+	} else if width < 1 {
+		return nil, errors.NewErrInvalidParameter("width", errors.NewErrGTE(1))
+	} else if height < 1 {
+		return nil, errors.NewErrInvalidParameter("height", errors.NewErrGTE(1))
+	}
+
+	data, err := json.Marshal(castHeader{
+		Version: 2,
+		Width:   width,
+		Height:  height,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = w.Write(append(data, '\n'))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CastRecorder{
+		w:     w,
+		start: time.Now(),
+	}, nil
+}
+
+// Record records a screen at the given time.
+//
+// Parameters:
+//   - elapsed: The time elapsed since the start of the recording.
+//   - screen: The screen to record.
+//
+// Returns:
+//   - error: An error if the screen could not be recorded.
+//
+// Errors:
+//   - errors.NilReceiver: If the recorder is nil.
+//   - *errors.ErrInvalidParameter: If the screen is nil.
+//   - error: Any error returned by the underlying writer.
+func (r *CastRecorder) Record(elapsed time.Duration, screen table.Reader[rune]) error {
+	if r == nil {
+		return errors.NilReceiver
+	} else if screen == nil {
+		return errors.NewErrNilParameter("screen")
+	}
+
+	r.buff = r.renderer.render(r.buff[:0], screen)
+
+	data, err := json.Marshal([]any{elapsed.Seconds(), "o", string(r.buff)})
+	if err != nil {
+		return err
+	}
+
+	_, err = r.w.Write(append(data, '\n'))
+	return err
+}
+
+// Capture records a screen at the current time; that is, the time elapsed since
+// the recorder was created.
+//
+// Parameters:
+//   - screen: The screen to record.
+//
+// Returns:
+//   - error: An error if the screen could not be recorded.
+func (r *CastRecorder) Capture(screen table.Reader[rune]) error {
+	if r == nil {
+		return errors.NilReceiver
+	}
+
+	return r.Record(time.Since(r.start), screen)
+}
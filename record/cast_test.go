@@ -0,0 +1,112 @@
+package record_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/record"
+)
+
+// castOutputs returns the data of the output events of an asciicast v2 file.
+func castOutputs(t *testing.T, data []byte) []string {
+	t.Helper()
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Scan() // Header.
+
+	var outputs []string
+
+	for sc.Scan() {
+		var event [3]any
+
+		err := json.Unmarshal(sc.Bytes(), &event)
+		if err != nil {
+			t.Fatalf("invalid event %q: %v", sc.Text(), err)
+		}
+
+		outputs = append(outputs, event[2].(string))
+	}
+
+	return outputs
+}
+
+// recordCast records the given screens, one second apart, and returns the outputs.
+func recordCast(t *testing.T, screens ...[][]rune) []string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	rec, err := record.NewCastRecorder(&buf, 80, 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, rows := range screens {
+		screen, err := table.NewRuneTableFromRows(rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = rec.Record(time.Duration(i)*time.Second, screen)
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	return castOutputs(t, buf.Bytes())
+}
+
+func TestCastRecorderRedrawsChangedRows(t *testing.T) {
+	outputs := recordCast(t, [][]rune{[]rune("ab"), []rune("cd")}, [][]rune{[]rune("ab"), []rune("cx")})
+
+	want := []string{
+		"\x1b[H\x1b[2J\x1b[1;1Hab\x1b[2;1Hcd",
+		"\x1b[2;1Hcx",
+	}
+
+	if len(outputs) != len(want) {
+		t.Fatalf("got %q, want %q", outputs, want)
+	}
+
+	for i := range want {
+		if outputs[i] != want[i] {
+			t.Errorf("frame %d: got %q, want %q", i, outputs[i], want[i])
+		}
+	}
+}
+
+func TestCastRecorderSanitizesCells(t *testing.T) {
+	tests := []struct {
+		name string
+		row  []rune
+		want string
+	}{
+		{"zero", []rune{'a', 0, 'b'}, "a b"},
+		{"escape", []rune{'a', 0x1b, '['}, "a�["},
+		{"C1 control", []rune{0x9b, '2', 'J'}, "�2J"},
+		{"invalid", []rune{0xD800, 'a'}, "�a"},
+		{"combining mark", []rune{'e', 0x301, 'x'}, "e x"},
+		{"wide", []rune{'界', 0, 'a'}, "界a"},
+		{"wide in the last column", []rune{'a', '界'}, "a "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs := recordCast(t, [][]rune{tt.row})
+
+			got := strings.TrimPrefix(outputs[0], "\x1b[H\x1b[2J\x1b[1;1H")
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			if table.StringWidth(got) != len(tt.row) {
+				t.Errorf("%q takes %d columns, want %d", got, table.StringWidth(got), len(tt.row))
+			}
+		})
+	}
+}
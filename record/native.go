@@ -0,0 +1,172 @@
+package record
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/codec"
+)
+
+// Frame is a recorded screen.
+type Frame struct {
+	// Elapsed is the time elapsed since the start of the recording.
+	Elapsed time.Duration
+
+	// Screen is the recorded screen.
+	Screen *table.Table[rune]
+}
+
+// NativeRecorder records screens in a compact binary format: a stream of
+// codec frames, each preceded by its timestamp in microseconds as an unsigned
+// varint. Screens that barely change cost a few bytes each. As with CastRecorder,
+// styled screens are not supported.
+type NativeRecorder struct {
+	w       io.Writer
+	encoder *codec.Encoder[rune]
+	start   time.Time
+	buff    []byte
+}
+
+// NewNativeRecorder creates a new recorder.
+//
+// Parameters:
+//   - w: The writer to write the recording to.
+//
+// Returns:
+//   - *NativeRecorder: The new recorder.
+//   - error: An error if the recorder could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+func NewNativeRecorder(w io.Writer) (*NativeRecorder, error) {
+	if w == nil {
+		return nil, errors.NewErrNilParameter("w")
+	}
+
+	encoder, err := codec.NewEncoder(w, codec.Rune)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeRecorder{
+		w:       w,
+		encoder: encoder,
+		start:   time.Now(),
+	}, nil
+}
+
+// Record records a screen at the given time.
+//
+// Parameters:
+//   - elapsed: The time elapsed since the start of the recording. Negative values
+//     are treated as 0.
+//   - screen: The screen to record.
+//
+// Returns:
+//   - error: An error if the screen could not be recorded.
+//
+// Errors:
+//   - errors.NilReceiver: If the recorder is nil.
+//   - *errors.ErrInvalidParameter: If the screen is nil.
+//   - error: Any error returned by the underlying writer.
+func (r *NativeRecorder) Record(elapsed time.Duration, screen table.Reader[rune]) error {
+	if r == nil {
+		return errors.NilReceiver
+	} else if screen == nil {
+		return errors.NewErrNilParameter("screen")
+	}
+
+	r.buff = binary.AppendUvarint(r.buff[:0], uint64(max(elapsed.Microseconds(), 0)))
+
+	_, err := r.w.Write(r.buff)
+	if err != nil {
+		return err
+	}
+
+	return r.encoder.Encode(screen)
+}
+
+// Capture records a screen at the current time; that is, the time elapsed since
+// the recorder was created.
+//
+// Parameters:
+//   - screen: The screen to record.
+//
+// Returns:
+//   - error: An error if the screen could not be recorded.
+func (r *NativeRecorder) Capture(screen table.Reader[rune]) error {
+	if r == nil {
+		return errors.NilReceiver
+	}
+
+	return r.Record(time.Since(r.start), screen)
+}
+
+// NativeReader reads the frames of a recording written by a NativeRecorder.
+type NativeReader struct {
+	r       *bufio.Reader
+	decoder *codec.Decoder[rune]
+}
+
+// NewNativeReader creates a new reader of recordings.
+//
+// Parameters:
+//   - r: The reader to read the recording from.
+//
+// Returns:
+//   - *NativeReader: The new reader.
+//   - error: An error if the reader could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+func NewNativeReader(r io.Reader) (*NativeReader, error) {
+	if r == nil {
+		return nil, errors.NewErrNilParameter("r")
+	}
+
+	// The decoder reuses this buffered reader, so both read from the same buffer.
+	br := bufio.NewReader(r)
+
+	decoder, err := codec.NewDecoder(br, codec.Rune)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeReader{
+		r:       br,
+		decoder: decoder,
+	}, nil
+}
+
+// Next reads the next frame of the recording.
+//
+// Returns:
+//   - Frame: The frame.
+//   - error: io.EOF if there are no more frames, or an error if the frame could
+//     not be read.
+func (r *NativeReader) Next() (Frame, error) {
+	if r == nil {
+		return Frame{}, errors.NilReceiver
+	}
+
+	micros, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return Frame{}, err
+	}
+
+	screen, err := r.decoder.Decode()
+	if err == io.EOF {
+		return Frame{}, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return Frame{}, err
+	}
+
+	return Frame{
+		Elapsed: time.Duration(micros) * time.Microsecond,
+		Screen:  screen,
+	}, nil
+}
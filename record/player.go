@@ -0,0 +1,179 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// Player replays recordings to a writer, usually a terminal.
+type Player struct {
+	// Speed is the playback speed. 2 plays twice as fast as real time. Values
+	// less than or equal to 0 mean real time.
+	Speed float64
+
+	// MaxIdle, if positive, caps the wait between two frames.
+	MaxIdle time.Duration
+
+	// Sleep is the function used to wait between two frames. If nil, time.Sleep
+	// is used.
+	Sleep func(d time.Duration)
+}
+
+// wait waits until the given time of the recording.
+//
+// Parameters:
+//   - last: The time of the previous frame.
+//   - next: The time of the next frame.
+func (p Player) wait(last, next time.Duration) {
+	d := next - last
+	if d <= 0 {
+		return
+	}
+
+	if p.Speed > 0 {
+		d = time.Duration(float64(d) / p.Speed)
+	}
+
+	if p.MaxIdle > 0 {
+		d = min(d, p.MaxIdle)
+	}
+
+	if p.Sleep != nil {
+		p.Sleep(d)
+	} else {
+		time.Sleep(d)
+	}
+}
+
+// PlayCast replays an asciicast v2 file. Only output events are replayed.
+//
+// Parameters:
+//   - w: The writer to replay to.
+//   - r: The reader to read the file from.
+//
+// Returns:
+//   - error: An error if the file could not be replayed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or r is nil.
+//   - error: If the file is not a valid asciicast v2 file, or any error returned
+//     by w or r.
+func (p Player) PlayCast(w io.Writer, r io.Reader) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	} else if r == nil {
+		return errors.NewErrNilParameter("r")
+	}
+
+	br := bufio.NewReader(r)
+
+	line, err := br.ReadBytes('\n')
+	if err != nil && (err != io.EOF || len(line) == 0) {
+		return err
+	}
+
+	var header castHeader
+
+	err = json.Unmarshal(line, &header)
+	if err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	} else if header.Version != 2 {
+		return fmt.Errorf("unsupported version %d", header.Version)
+	}
+
+	var last time.Duration
+
+	for lineno := 2; ; lineno++ {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF && len(bytes.TrimSpace(line)) == 0 {
+			return nil
+		} else if err != nil && err != io.EOF {
+			return err
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var event [3]any
+
+		err = json.Unmarshal(line, &event)
+		if err != nil {
+			return fmt.Errorf("invalid event on line %d: %w", lineno, err)
+		}
+
+		seconds, ok1 := event[0].(float64)
+		kind, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+
+		if !ok1 || !ok2 || !ok3 {
+			return fmt.Errorf("invalid event on line %d", lineno)
+		}
+
+		if kind != "o" {
+			continue
+		}
+
+		next := time.Duration(seconds * float64(time.Second))
+		p.wait(last, next)
+		last = next
+
+		_, err = io.WriteString(w, data)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// PlayNative replays a recording written by a NativeRecorder, drawing each frame
+// with ANSI escape sequences.
+//
+// Parameters:
+//   - w: The writer to replay to.
+//   - r: The reader to read the recording from.
+//
+// Returns:
+//   - error: An error if the recording could not be replayed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or r is nil.
+//   - error: If the recording is not valid, or any error returned by w or r.
+func (p Player) PlayNative(w io.Writer, r io.Reader) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	}
+
+	reader, err := NewNativeReader(r)
+	if err != nil {
+		return err
+	}
+
+	var renderer screenRenderer
+	var last time.Duration
+	var buff []byte
+
+	for {
+		frame, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		p.wait(last, frame.Elapsed)
+		last = frame.Elapsed
+
+		buff = renderer.render(buff[:0], frame.Screen)
+
+		_, err = w.Write(buff)
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package record_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/record"
+)
+
+func TestNativeRoundTrip(t *testing.T) {
+	screens := [][][]rune{
+		{[]rune("ab"), []rune("cd")},
+		{[]rune("ab"), []rune("cx")},
+		{[]rune("abc")},
+	}
+
+	var buf bytes.Buffer
+
+	rec, err := record.NewNativeRecorder(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, rows := range screens {
+		err = rec.Record(time.Duration(i)*time.Second, must(table.NewRuneTableFromRows(rows)))
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	reader, err := record.NewNativeReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, rows := range screens {
+		frame, err := reader.Next()
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+
+		if frame.Elapsed != time.Duration(i)*time.Second {
+			t.Errorf("frame %d: Elapsed is %v, want %v", i, frame.Elapsed, time.Duration(i)*time.Second)
+		}
+
+		if !reflect.DeepEqual(frame.Screen.FullTable(), rows) {
+			t.Errorf("frame %d: got %q, want %q", i, frame.Screen.FullTable(), rows)
+		}
+	}
+
+	_, err = reader.Next()
+	if err != io.EOF {
+		t.Errorf("after the last frame: got %v, want io.EOF", err)
+	}
+}
+
+func TestPlayerWaits(t *testing.T) {
+	var cast bytes.Buffer
+
+	rec, err := record.NewCastRecorder(&cast, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, s := range []string{"ab", "\x1bb", "cd"} {
+		err = rec.Record(time.Duration(i)*4*time.Second, must(table.NewRuneTableFromRows([][]rune{[]rune(s)})))
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+	}
+
+	var waits []time.Duration
+
+	p := record.Player{
+		Speed:   2,
+		MaxIdle: time.Second,
+		Sleep:   func(d time.Duration) { waits = append(waits, d) },
+	}
+
+	var out bytes.Buffer
+
+	err = p.PlayCast(&out, &cast)
+	if err != nil {
+		t.Fatalf("PlayCast: %v", err)
+	}
+
+	if want := []time.Duration{time.Second, time.Second}; !reflect.DeepEqual(waits, want) {
+		t.Errorf("waits are %v, want %v", waits, want)
+	}
+
+	want := "\x1b[H\x1b[2J\x1b[1;1Hab\x1b[1;1H�b\x1b[1;1Hcd"
+	if out.String() != want {
+		t.Errorf("got %q, want %q", out.String(), want)
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
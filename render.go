@@ -0,0 +1,763 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// BorderStyle is the style of the borders drawn by StringTable.Render.
+type BorderStyle int
+
+const (
+	// BorderASCII draws the borders with '+', '-' and '|'.
+	BorderASCII BorderStyle = iota
+
+	// BorderUnicode draws the borders with box-drawing characters.
+	BorderUnicode
+
+	// BorderMarkdown draws the table as a GitHub Flavored Markdown pipe table.
+	BorderMarkdown
+
+	// BorderNone draws no borders; columns are separated by two spaces.
+	BorderNone
+)
+
+// String implements the fmt.Stringer interface.
+func (b BorderStyle) String() string {
+	switch b {
+	case BorderASCII:
+		return "ascii"
+	case BorderUnicode:
+		return "unicode"
+	case BorderMarkdown:
+		return "markdown"
+	case BorderNone:
+		return "none"
+	default:
+		return fmt.Sprintf("BorderStyle(%d)", int(b))
+	}
+}
+
+// Alignment is the horizontal alignment of the content of a column.
+type Alignment int
+
+const (
+	// AlignLeft aligns the content to the left of the column.
+	AlignLeft Alignment = iota
+
+	// AlignCenter centers the content within the column.
+	AlignCenter
+
+	// AlignRight aligns the content to the right of the column.
+	AlignRight
+)
+
+// String implements the fmt.Stringer interface.
+func (a Alignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignCenter:
+		return "center"
+	case AlignRight:
+		return "right"
+	default:
+		return fmt.Sprintf("Alignment(%d)", int(a))
+	}
+}
+
+// Overflow tells what happens to the content of a cell that is wider than the
+// maximum width of its column.
+type Overflow int
+
+const (
+	// OverflowTruncate cuts the content and ends it with an ellipsis.
+	OverflowTruncate Overflow = iota
+
+	// OverflowWrap breaks the content into several lines, at spaces if possible.
+	OverflowWrap
+)
+
+// ColumnOptions are the rendering options of a single column.
+type ColumnOptions struct {
+	// Align is the alignment of the content of the column.
+	Align Alignment
+
+	// MinWidth is the minimum width, in terminal columns, of the column.
+	MinWidth int
+
+	// MaxWidth is the maximum width, in terminal columns, of the column. 0 means
+	// unbounded.
+	MaxWidth int
+}
+
+// RenderOptions are the options of StringTable.Render.
+type RenderOptions struct {
+	// Border is the style of the borders.
+	Border BorderStyle
+
+	// Header tells whether the first row of the table is a header row.
+	Header bool
+
+	// Columns are the options of each column. Columns without options are left
+	// aligned and unbounded.
+	Columns []ColumnOptions
+
+	// Overflow tells how the content wider than its column is handled.
+	Overflow Overflow
+}
+
+// column returns the options of the given column.
+//
+// Parameters:
+//   - idx: The index of the column.
+//
+// Returns:
+//   - ColumnOptions: The options of the column.
+func (o RenderOptions) column(idx int) ColumnOptions {
+	if idx < len(o.Columns) {
+		return o.Columns[idx]
+	}
+
+	return ColumnOptions{}
+}
+
+// borderSet is the set of runes used to draw the borders of a table.
+type borderSet struct {
+	// top, middle and bottom are the left corner, the junction and the right corner
+	// of the top border, the header separator and the bottom border, respectively.
+	top, middle, bottom [3]rune
+
+	// horizontal and vertical are the runes of the horizontal and vertical lines.
+	horizontal, vertical rune
+
+	// up and down are the junctions of the header separator where only the row
+	// above or only the row below it, respectively, has a vertical border.
+	up, down rune
+}
+
+var (
+	// asciiBorders are the borders of the BorderASCII style.
+	asciiBorders = borderSet{
+		top:        [3]rune{'+', '+', '+'},
+		middle:     [3]rune{'+', '+', '+'},
+		bottom:     [3]rune{'+', '+', '+'},
+		horizontal: '-',
+		vertical:   '|',
+		up:         '+',
+		down:       '+',
+	}
+
+	// unicodeBorders are the borders of the BorderUnicode style.
+	unicodeBorders = borderSet{
+		top:        [3]rune{'┌', '┬', '┐'},
+		middle:     [3]rune{'├', '┼', '┤'},
+		bottom:     [3]rune{'└', '┴', '┘'},
+		horizontal: '─',
+		vertical:   '│',
+		up:         '┴',
+		down:       '┬',
+	}
+)
+
+// Render prints the table as text.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+//
+// Widths are measured in terminal columns (see StringWidth) and newlines within
+// cells start new lines within the same row, except with BorderMarkdown where
+// they become <br> elements. Since Markdown requires a header, an empty one is
+// printed with BorderMarkdown when opts.Header is false.
+func (t StringTable) Render(w io.Writer, opts RenderOptions) error {
+	return renderText(w, t.table, t.width, opts, nil)
+}
+
+// renderText prints the given cells as text.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - cells: The cells of the table.
+//   - width: The number of columns of the table.
+//   - opts: The rendering options.
+//   - spans: The merged cells of the table.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+func renderText(w io.Writer, cells [][]string, width int, opts RenderOptions, spans []Span) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	}
+
+	r := newTextRenderer(cells, width, opts, clipSpans(spans, width, len(cells)))
+
+	bw := bufio.NewWriter(w)
+	r.write(bw)
+
+	return bw.Flush()
+}
+
+// textRenderer is the state of renderText.
+type textRenderer struct {
+	opts RenderOptions
+
+	// widths and heights are the number of terminal columns of each column and
+	// the number of lines of each row, respectively.
+	widths, heights []int
+
+	// spans are the merged cells of the table.
+	spans []Span
+
+	// lines are the lines of the cell at the origin of each span. Covered cells
+	// have no lines.
+	lines [][][]string
+}
+
+// newTextRenderer lays out the given cells.
+//
+// Parameters:
+//   - cells: The cells of the table.
+//   - width: The number of columns of the table.
+//   - opts: The rendering options.
+//   - spans: The merged cells of the table.
+//
+// Returns:
+//   - *textRenderer: The renderer. Never returns nil.
+func newTextRenderer(cells [][]string, width int, opts RenderOptions, spans []Span) *textRenderer {
+	r := &textRenderer{
+		opts:   opts,
+		widths: make([]int, width),
+	}
+
+	if opts.Border == BorderMarkdown {
+		cells = escapeMarkdown(blankCovered(cells, spans))
+		spans = nil
+
+		if !opts.Header {
+			cells = append([][]string{make([]string, width)}, cells...)
+		}
+	}
+
+	r.spans = spans
+	r.heights = make([]int, len(cells))
+
+	// Columns are sized after the cells that span a single column, and then widened
+	// so that the content of the other cells fits.
+	for y, row := range cells {
+		for x, cell := range row {
+			span, ok := r.spanAt(x, y)
+			if ok && (span.X != x || span.Y != y || span.Width != 1) {
+				continue
+			}
+
+			r.widths[x] = max(r.widths[x], maxLineWidth(cell))
+		}
+	}
+
+	for x := range r.widths {
+		col := opts.column(x)
+
+		if col.MaxWidth > 0 {
+			r.widths[x] = min(r.widths[x], col.MaxWidth)
+		}
+
+		r.widths[x] = max(r.widths[x], col.MinWidth)
+
+		if opts.Border == BorderMarkdown {
+			// A delimiter row needs at least three characters per column.
+			r.widths[x] = max(r.widths[x], 3)
+		}
+	}
+
+	for _, span := range r.spans {
+		if span.Width == 1 {
+			continue
+		}
+
+		last := min(span.X+span.Width, width) - 1
+		gap := maxLineWidth(cells[span.Y][span.X]) - r.spanWidth(span)
+
+		if gap > 0 && opts.column(last).MaxWidth == 0 {
+			r.widths[last] += gap
+		}
+	}
+
+	// Rows are sized in the same way, after the cells that span a single row.
+	r.lines = make([][][]string, len(cells))
+
+	for y, row := range cells {
+		r.lines[y] = make([][]string, len(row))
+
+		for x, cell := range row {
+			span, _ := r.spanAt(x, y)
+			if span.X != x || span.Y != y {
+				continue
+			}
+
+			lines := r.layoutCell(cell, r.spanWidth(span), r.opts.column(x).Align)
+			r.lines[y][x] = lines
+
+			if span.Height == 1 {
+				r.heights[y] = max(r.heights[y], len(lines))
+			}
+		}
+
+		r.heights[y] = max(r.heights[y], 1)
+	}
+
+	for _, span := range r.spans {
+		if span.Height == 1 {
+			continue
+		}
+
+		last := min(span.Y+span.Height, len(cells)) - 1
+
+		var height int
+
+		for y := span.Y; y <= last; y++ {
+			height += r.heights[y]
+		}
+
+		gap := len(r.lines[span.Y][span.X]) - height
+		if gap > 0 {
+			r.heights[last] += gap
+		}
+	}
+
+	return r
+}
+
+// spanAt returns the merged cell that contains the given cell, clipped to the
+// table.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - Span: The merged cell. A 1x1 span at the given coordinates if the cell is
+//     not merged.
+//   - bool: True if the cell is merged, false otherwise.
+func (r textRenderer) spanAt(x, y int) (Span, bool) {
+	span, ok := spanAt(r.spans, x, y)
+
+	span.Width = min(span.Width, len(r.widths)-span.X)
+	span.Height = min(span.Height, len(r.heights)-span.Y)
+
+	return span, ok
+}
+
+// spanWidth returns the number of terminal columns available to the content of
+// the given merged cell; that is, the width of its columns and of the borders
+// between them.
+//
+// Parameters:
+//   - span: The merged cell.
+//
+// Returns:
+//   - int: The width of the merged cell.
+func (r textRenderer) spanWidth(span Span) int {
+	width := (span.Width - 1) * len(r.separator())
+
+	for x := span.X; x < span.X+span.Width && x < len(r.widths); x++ {
+		width += r.widths[x]
+	}
+
+	return width
+}
+
+// separator returns the text between the content of two adjacent cells that are
+// not merged.
+//
+// Returns:
+//   - string: The separator.
+func (r textRenderer) separator() string {
+	if r.opts.Border == BorderNone {
+		return "  "
+	}
+
+	return " | "
+}
+
+// layoutCell splits the content of a cell into padded lines that fit in the
+// given width.
+//
+// Parameters:
+//   - cell: The content of the cell.
+//   - width: The number of terminal columns of the cell.
+//   - align: The alignment of the content.
+//
+// Returns:
+//   - []string: The lines of the cell. Never empty.
+func (r textRenderer) layoutCell(cell string, width int, align Alignment) []string {
+	var lines []string
+
+	for _, line := range strings.Split(cell, "\n") {
+		if StringWidth(line) <= width {
+			lines = append(lines, line)
+		} else if r.opts.Overflow == OverflowWrap {
+			lines = append(lines, wrapWidth(line, width)...)
+		} else {
+			lines = append(lines, ellipsize(line, width))
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = padWidth(line, width, align)
+	}
+
+	return lines
+}
+
+// write prints the laid-out table.
+//
+// Parameters:
+//   - w: The writer to print to.
+func (r textRenderer) write(w *bufio.Writer) {
+	var borders *borderSet
+
+	switch r.opts.Border {
+	case BorderASCII:
+		borders = &asciiBorders
+	case BorderUnicode:
+		borders = &unicodeBorders
+	}
+
+	if borders != nil && len(r.widths) > 0 {
+		r.writeRule(w, borders.top, borders, 0, 0)
+	}
+
+	header := r.opts.Header || r.opts.Border == BorderMarkdown
+
+	for y := range r.heights {
+		r.writeRow(w, y, borders)
+
+		if y != 0 || !header {
+			continue
+		}
+
+		if r.opts.Border == BorderMarkdown {
+			r.writeDelimiter(w)
+		} else if borders != nil {
+			r.writeRule(w, borders.middle, borders, 0, 1)
+		}
+	}
+
+	if borders != nil && len(r.widths) > 0 {
+		last := max(len(r.heights)-1, 0)
+		r.writeRule(w, borders.bottom, borders, last, last)
+	}
+}
+
+// isBoundary checks whether a vertical border is drawn at the left of the given
+// cell; that is, whether the cell and the cell at its left are not merged.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - bool: True if there is a border, false otherwise.
+func (r textRenderer) isBoundary(x, y int) bool {
+	if y >= len(r.heights) {
+		return true
+	}
+
+	span, _ := r.spanAt(x, y)
+
+	return span.X == x
+}
+
+// writeRule prints a horizontal border between two rows.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - corners: The left corner, the junction and the right corner of the border.
+//   - borders: The borders of the table.
+//   - above: The row above the border.
+//   - below: The row below the border.
+//
+// Junctions are only drawn where at least one of the rows has a vertical border.
+func (r textRenderer) writeRule(w *bufio.Writer, corners [3]rune, borders *borderSet, above, below int) {
+	w.WriteRune(corners[0])
+
+	for x, width := range r.widths {
+		if x > 0 {
+			up, down := r.isBoundary(x, above), r.isBoundary(x, below)
+
+			switch {
+			case above != below && up && !down:
+				w.WriteRune(borders.up)
+			case above != below && !up && down:
+				w.WriteRune(borders.down)
+			case up || down:
+				w.WriteRune(corners[1])
+			default:
+				w.WriteRune(borders.horizontal)
+			}
+		}
+
+		for i := 0; i < width+2; i++ {
+			w.WriteRune(borders.horizontal)
+		}
+	}
+
+	w.WriteRune(corners[2])
+	w.WriteByte('\n')
+}
+
+// writeDelimiter prints the delimiter row of a Markdown table, with its
+// alignment markers.
+//
+// Parameters:
+//   - w: The writer to print to.
+func (r textRenderer) writeDelimiter(w *bufio.Writer) {
+	w.WriteByte('|')
+
+	for x, width := range r.widths {
+		w.WriteByte(' ')
+
+		align := r.opts.column(x).Align
+
+		if align == AlignCenter {
+			w.WriteByte(':')
+		} else {
+			w.WriteByte('-')
+		}
+
+		w.WriteString(strings.Repeat("-", width-2))
+
+		if align == AlignCenter || align == AlignRight {
+			w.WriteByte(':')
+		} else {
+			w.WriteByte('-')
+		}
+
+		w.WriteString(" |")
+	}
+
+	w.WriteByte('\n')
+}
+
+// writeRow prints a row of the table, which may span several lines.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - y: The index of the row.
+//   - borders: The borders of the table. Nil if there are none.
+func (r textRenderer) writeRow(w *bufio.Writer, y int, borders *borderSet) {
+	var vertical string
+
+	switch {
+	case borders != nil:
+		vertical = string(borders.vertical)
+	case r.opts.Border == BorderMarkdown:
+		vertical = "|"
+	}
+
+	for i := 0; i < r.heights[y]; i++ {
+		var line strings.Builder
+
+		for x := 0; x < len(r.widths); {
+			span, _ := r.spanAt(x, y)
+
+			// Index of the line within the merged cell.
+			idx := i
+			for row := span.Y; row < y; row++ {
+				idx += r.heights[row]
+			}
+
+			content := strings.Repeat(" ", r.spanWidth(span))
+
+			lines := r.lines[span.Y][span.X]
+			if idx < len(lines) {
+				content = lines[idx]
+			}
+
+			if vertical == "" {
+				if x > 0 {
+					line.WriteString("  ")
+				}
+
+				line.WriteString(content)
+			} else {
+				line.WriteString(vertical)
+				line.WriteByte(' ')
+				line.WriteString(content)
+				line.WriteByte(' ')
+			}
+
+			x = span.X + span.Width
+		}
+
+		line.WriteString(vertical)
+
+		if vertical == "" {
+			w.WriteString(strings.TrimRight(line.String(), " "))
+		} else {
+			w.WriteString(line.String())
+		}
+
+		w.WriteByte('\n')
+	}
+}
+
+// maxLineWidth returns the width of the widest line of the given text.
+//
+// Parameters:
+//   - text: The text to measure.
+//
+// Returns:
+//   - int: The number of terminal columns of the widest line.
+func maxLineWidth(text string) int {
+	var width int
+
+	for _, line := range strings.Split(text, "\n") {
+		width = max(width, StringWidth(line))
+	}
+
+	return width
+}
+
+// blankCovered returns a copy of the given cells in which the cells covered by
+// the given spans are empty.
+//
+// Parameters:
+//   - cells: The cells of the table.
+//   - spans: The merged cells of the table.
+//
+// Returns:
+//   - [][]string: The cells. The given cells if there are no spans.
+func blankCovered(cells [][]string, spans []Span) [][]string {
+	if len(spans) == 0 {
+		return cells
+	}
+
+	blanked := make([][]string, 0, len(cells))
+
+	for y, row := range cells {
+		new_row := make([]string, len(row))
+
+		for x, cell := range row {
+			span, _ := spanAt(spans, x, y)
+			if span.X == x && span.Y == y {
+				new_row[x] = cell
+			}
+		}
+
+		blanked = append(blanked, new_row)
+	}
+
+	return blanked
+}
+
+// escapeMarkdown escapes the pipes of the given cells so that they do not end
+// the cells of a Markdown table, and replaces their newlines with <br> elements
+// so that they do not end the rows.
+//
+// Parameters:
+//   - cells: The cells to escape.
+//
+// Returns:
+//   - [][]string: The escaped cells. The given cells are not modified.
+func escapeMarkdown(cells [][]string) [][]string {
+	escaped := make([][]string, 0, len(cells))
+
+	for _, row := range cells {
+		new_row := make([]string, 0, len(row))
+
+		for _, cell := range row {
+			new_row = append(new_row, markdownEscaper.Replace(cell))
+		}
+
+		escaped = append(escaped, new_row)
+	}
+
+	return escaped
+}
+
+// ellipsize truncates the given string so that it fits in the given number of
+// columns, ending it with an ellipsis.
+//
+// Parameters:
+//   - s: The string to truncate.
+//   - width: The number of columns.
+//
+// Returns:
+//   - string: The truncated string.
+func ellipsize(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	prefix, _ := truncateWidth(s, width-1)
+
+	return prefix + "…"
+}
+
+// wrapWidth breaks the given string into lines that fit in the given number of
+// columns. Lines are broken at spaces when possible.
+//
+// Parameters:
+//   - s: The string to wrap.
+//   - width: The number of columns.
+//
+// Returns:
+//   - []string: The lines. Never empty.
+func wrapWidth(s string, width int) []string {
+	if width <= 0 {
+		return []string{""}
+	}
+
+	var lines []string
+	var current string
+
+	for _, word := range strings.Fields(s) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+
+		if StringWidth(candidate) <= width {
+			current = candidate
+			continue
+		}
+
+		if current != "" {
+			lines = append(lines, current)
+		}
+
+		for StringWidth(word) > width {
+			prefix, rest := truncateWidth(word, width)
+			if prefix == "" {
+				// The rune is wider than the column; let it overflow.
+				_, size := utf8.DecodeRuneInString(word)
+				prefix, rest = word[:size], word[size:]
+			}
+
+			lines = append(lines, prefix)
+			word = rest
+		}
+
+		current = word
+	}
+
+	if current != "" || len(lines) == 0 {
+		lines = append(lines, current)
+	}
+
+	return lines
+}
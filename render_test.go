@@ -0,0 +1,123 @@
+package table_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestStringTableRender(t *testing.T) {
+	src := must(table.NewStringTableFromRows([][]string{{"name", "qty"}, {"apple pie", "3"}, {"界界", "12"}}))
+
+	tests := []struct {
+		name string
+		opts table.RenderOptions
+		want string
+	}{
+		{
+			name: "none",
+			opts: table.RenderOptions{Border: table.BorderNone},
+			want: "name       qty\n" +
+				"apple pie  3\n" +
+				"界界       12\n",
+		},
+		{
+			name: "markdown without header",
+			opts: table.RenderOptions{Border: table.BorderMarkdown, Columns: []table.ColumnOptions{{}, {Align: table.AlignRight}}},
+			want: "|           |     |\n" +
+				"| --------- | --: |\n" +
+				"| name      | qty |\n" +
+				"| apple pie |   3 |\n" +
+				"| 界界      |  12 |\n",
+		},
+		{
+			name: "truncated",
+			opts: table.RenderOptions{
+				Border:  table.BorderASCII,
+				Header:  true,
+				Columns: []table.ColumnOptions{{MaxWidth: 5}, {Align: table.AlignCenter, MinWidth: 5}},
+			},
+			want: "+-------+-------+\n" +
+				"| name  |  qty  |\n" +
+				"+-------+-------+\n" +
+				"| appl… |   3   |\n" +
+				"| 界界  |  12   |\n" +
+				"+-------+-------+\n",
+		},
+		{
+			name: "wrapped",
+			opts: table.RenderOptions{
+				Border:   table.BorderASCII,
+				Overflow: table.OverflowWrap,
+				Columns:  []table.ColumnOptions{{MaxWidth: 5}},
+			},
+			want: "+-------+-----+\n" +
+				"| name  | qty |\n" +
+				"| apple | 3   |\n" +
+				"| pie   |     |\n" +
+				"| 界界  | 12  |\n" +
+				"+-------+-----+\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := src.Render(&buf, tt.opts)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderNilWriter(t *testing.T) {
+	src := must(table.NewStringTable(1, 1))
+
+	if src.Render(nil, table.RenderOptions{}) == nil {
+		t.Errorf("Render(nil) succeeded")
+	}
+}
+
+func TestRenderMultilineCells(t *testing.T) {
+	src := must(table.NewStringTableFromRows([][]string{{"a", "b\nc"}, {"", "d"}}))
+
+	var buf bytes.Buffer
+
+	err := src.Render(&buf, table.RenderOptions{Border: table.BorderUnicode, Header: true})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	want := "┌───┬───┐\n" +
+		"│ a │ b │\n" +
+		"│   │ c │\n" +
+		"├───┼───┤\n" +
+		"│   │ d │\n" +
+		"└───┴───┘\n"
+
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestRenderEmptyTable(t *testing.T) {
+	src := must(table.NewStringTable(0, 0))
+
+	var buf bytes.Buffer
+
+	err := src.Render(&buf, table.RenderOptions{Border: table.BorderUnicode})
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	if buf.Len() != 0 {
+		t.Errorf("got %q, want nothing", buf.String())
+	}
+}
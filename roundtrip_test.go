@@ -0,0 +1,88 @@
+package table_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// encodedTable is a generated table, such as *table.IntTable, that can be encoded.
+type encodedTable interface {
+	Width() int
+	Height() int
+}
+
+// tableCodec is a way to encode and decode tables.
+type tableCodec struct {
+	marshal   func(src any) ([]byte, error)
+	unmarshal func(data []byte, dst any) error
+}
+
+var (
+	// jsonCodec encodes tables as JSON.
+	jsonCodec = tableCodec{
+		marshal:   json.Marshal,
+		unmarshal: json.Unmarshal,
+	}
+
+	// binaryCodec encodes tables with their MarshalBinary method.
+	binaryCodec = tableCodec{
+		marshal: func(src any) ([]byte, error) {
+			return src.(encoding.BinaryMarshaler).MarshalBinary()
+		},
+		unmarshal: func(data []byte, dst any) error {
+			return dst.(encoding.BinaryUnmarshaler).UnmarshalBinary(data)
+		},
+	}
+)
+
+// roundTripCase is a table to encode and decode back.
+type roundTripCase struct {
+	name string
+	src  encodedTable
+
+	// equal compares the cells of the decoded table with the cells of src. If
+	// nil, reflect.DeepEqual is used.
+	equal func(got, want any) bool
+}
+
+// testRoundTrip checks that decoding the encoding of each table gives the table
+// back.
+func testRoundTrip(t *testing.T, codec tableCodec, tests []roundTripCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := codec.marshal(tt.src)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+
+			got := reflect.New(reflect.TypeOf(tt.src).Elem()).Interface().(encodedTable)
+
+			err = codec.unmarshal(data, got)
+			if err != nil {
+				t.Fatalf("unmarshal(%q): %v", data, err)
+			}
+
+			if got.Width() != tt.src.Width() || got.Height() != tt.src.Height() {
+				t.Fatalf("got a %dx%d table, want %dx%d", got.Width(), got.Height(), tt.src.Width(), tt.src.Height())
+			}
+
+			equal := tt.equal
+			if equal == nil {
+				equal = reflect.DeepEqual
+			}
+
+			if !equal(fullTable(got), fullTable(tt.src)) {
+				t.Errorf("got %v, want %v (encoded as %q)", fullTable(got), fullTable(tt.src), data)
+			}
+		})
+	}
+}
+
+// fullTable returns the cells of a generated table.
+func fullTable(tbl encodedTable) any {
+	return reflect.ValueOf(tbl).MethodByName("FullTable").Call(nil)[0].Interface()
+}
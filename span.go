@@ -0,0 +1,359 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/go-commons/ints"
+	"github.com/PlayerR9/table/geom"
+)
+
+// Span is a rectangle of cells that are merged into a single cell. The top-left
+// cell of the rectangle is the origin of the span and holds its content; the other
+// cells are said to be covered by the span.
+type Span = geom.Rect
+
+// CoveredWritePolicy tells what happens when a cell covered by a span is written.
+type CoveredWritePolicy int
+
+const (
+	// RedirectCovered writes the cell at the origin of the span instead.
+	RedirectCovered CoveredWritePolicy = iota
+
+	// RejectCovered ignores the write.
+	RejectCovered
+)
+
+// String implements the fmt.Stringer interface.
+func (p CoveredWritePolicy) String() string {
+	switch p {
+	case RedirectCovered:
+		return "redirect"
+	case RejectCovered:
+		return "reject"
+	default:
+		return fmt.Sprintf("CoveredWritePolicy(%d)", int(p))
+	}
+}
+
+// MergedTable is a string table in which rectangles of cells can be merged
+// into a single cell that spans several rows and/or columns.
+type MergedTable struct {
+	table  *StringTable
+	spans  []Span
+	policy CoveredWritePolicy
+}
+
+var _ ReadWriter[string] = (*MergedTable)(nil)
+
+// NewMergedTable creates a new merged table on top of the given table. Cells are
+// read from and written to the given table.
+//
+// Parameters:
+//   - table: The underlying table.
+//
+// Returns:
+//   - *MergedTable: The new merged table.
+//   - error: An error if the merged table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the table is nil.
+func NewMergedTable(table *StringTable) (*MergedTable, error) {
+	if table == nil {
+		return nil, errors.NewErrNilParameter("table")
+	}
+
+	return &MergedTable{
+		table: table,
+	}, nil
+}
+
+// Table returns the underlying table.
+//
+// Returns:
+//   - *StringTable: The underlying table. Never nil.
+func (m MergedTable) Table() *StringTable {
+	return m.table
+}
+
+// Width returns the width of the table.
+//
+// Returns:
+//   - int: The width of the table. Never negative.
+func (m MergedTable) Width() int {
+	return m.table.width
+}
+
+// Height returns the height of the table.
+//
+// Returns:
+//   - int: The height of the table. Never negative.
+func (m MergedTable) Height() int {
+	return m.table.height
+}
+
+// SetCoveredWritePolicy sets what happens when a covered cell is written. The
+// default is RedirectCovered.
+//
+// Parameters:
+//   - policy: The new policy.
+func (m *MergedTable) SetCoveredWritePolicy(policy CoveredWritePolicy) {
+	if m == nil {
+		return
+	}
+
+	m.policy = policy
+}
+
+// Merge merges the cells of the given rectangle. The content of the span is the
+// content of its origin; the content of the covered cells is ignored.
+//
+// Parameters:
+//   - span: The cells to merge.
+//
+// Returns:
+//   - error: An error if the cells could not be merged.
+//
+// Errors:
+//   - errors.NilReceiver: If the table is nil.
+//   - *errors.ErrInvalidParameter: If the span is empty, out of bounds or
+//     overlaps an existing span.
+func (m *MergedTable) Merge(span Span) error {
+	if m == nil {
+		return errors.NilReceiver
+	}
+
+	if span.Width < 1 {
+		return errors.NewErrInvalidParameter("span.Width", errors.NewErrGTE(1))
+	} else if span.Height < 1 {
+		return errors.NewErrInvalidParameter("span.Height", errors.NewErrGTE(1))
+	}
+
+	if span.X < 0 || span.X+span.Width > m.table.width {
+		return errors.NewErrInvalidParameter("span.X", ints.NewErrOutOfBounds(span.X, 0, m.table.width-span.Width+1))
+	} else if span.Y < 0 || span.Y+span.Height > m.table.height {
+		return errors.NewErrInvalidParameter("span.Y", ints.NewErrOutOfBounds(span.Y, 0, m.table.height-span.Height+1))
+	}
+
+	for _, other := range m.spans {
+		if span.Overlaps(other) {
+			return errors.NewErrInvalidParameter("span", fmt.Errorf("overlaps the span at (%d, %d)", other.X, other.Y))
+		}
+	}
+
+	m.spans = append(m.spans, span)
+
+	return nil
+}
+
+// Unmerge removes the span that contains the given cell.
+//
+// Parameters:
+//   - x: The x-coordinate of any cell of the span.
+//   - y: The y-coordinate of any cell of the span.
+//
+// Returns:
+//   - bool: True if a span was removed, false otherwise.
+func (m *MergedTable) Unmerge(x, y int) bool {
+	if m == nil {
+		return false
+	}
+
+	idx := slices.IndexFunc(m.spans, func(span Span) bool {
+		return span.Contains(geom.Pt(x, y))
+	})
+
+	if idx == -1 {
+		return false
+	}
+
+	m.spans = slices.Delete(m.spans, idx, idx+1)
+
+	return true
+}
+
+// SpanAt returns the span that contains the given cell.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - Span: The span that contains the cell. A 1x1 span at the given
+//     coordinates if the cell is not merged.
+//   - bool: True if the cell is part of a span, false otherwise.
+func (m MergedTable) SpanAt(x, y int) (Span, bool) {
+	return spanAt(m.spans, x, y)
+}
+
+// Spans returns an iterator over the spans of the table, in the order they were
+// merged.
+//
+// Returns:
+//   - iter.Seq[Span]: The iterator. Never returns nil.
+func (m MergedTable) Spans() iter.Seq[Span] {
+	return slices.Values(m.spans)
+}
+
+// CellAt returns the content of the cell at the given coordinates; that is, the
+// content of the origin of its span. However, out-of-bounds coordinates return "".
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - string: The content of the cell.
+func (m MergedTable) CellAt(x, y int) string {
+	span, _ := m.SpanAt(x, y)
+
+	return m.table.CellAt(span.X, span.Y)
+}
+
+// Cell returns an iterator that scans the table cell by cell, row by row. Covered
+// cells yield the content of the origin of their span; see CellAt.
+//
+// Returns:
+//   - iter.Seq[string]: The iterator. Never returns nil.
+func (m MergedTable) Cell() iter.Seq[string] {
+	fn := func(yield func(string) bool) {
+		for row := range m.Row() {
+			for _, cell := range row {
+				if !yield(cell) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Row returns an iterator that scans the table row by row. Covered cells hold the
+// content of the origin of their span; see CellAt. The yielded slice is reused
+// between rows.
+//
+// Returns:
+//   - iter.Seq[[]string]: The iterator. Never returns nil.
+func (m MergedTable) Row() iter.Seq[[]string] {
+	fn := func(yield func([]string) bool) {
+		row := make([]string, m.Width())
+
+		for y := 0; y < m.Height(); y++ {
+			for x := range row {
+				row[x] = m.CellAt(x, y)
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// WriteAt writes a cell to the table at the given coordinates. Writes to covered
+// cells are handled according to the covered write policy and out-of-bounds
+// coordinates do nothing.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write to the table.
+func (m MergedTable) WriteAt(x, y int, cell string) {
+	span, ok := m.SpanAt(x, y)
+
+	if ok && (span.X != x || span.Y != y) && m.policy == RejectCovered {
+		return
+	}
+
+	m.table.WriteAt(span.X, span.Y, cell)
+}
+
+// IsCovered checks whether the cell at the given coordinates is covered by a span;
+// that is, whether it is part of a span without being its origin.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - bool: True if the cell is covered, false otherwise.
+func (m MergedTable) IsCovered(x, y int) bool {
+	span, ok := m.SpanAt(x, y)
+
+	return ok && (span.X != x || span.Y != y)
+}
+
+// Render prints the table as text, like StringTable.Render does, with merged cells
+// drawn as a single cell.
+//
+// Parameters:
+//   - w: The writer to print to.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the table could not be printed.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+//
+// Since Markdown tables cannot merge cells, BorderMarkdown prints the content of
+// a span in its origin and leaves its covered cells empty.
+func (m MergedTable) Render(w io.Writer, opts RenderOptions) error {
+	return renderText(w, m.table.table, m.table.width, opts, m.spans)
+}
+
+// spanAt returns the span, among the given ones, that contains the given cell.
+//
+// Parameters:
+//   - spans: The spans to search.
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - Span: The span that contains the cell. A 1x1 span at the given coordinates
+//     if none does.
+//   - bool: True if a span contains the cell, false otherwise.
+func spanAt(spans []Span, x, y int) (Span, bool) {
+	for _, span := range spans {
+		if span.Contains(geom.Pt(x, y)) {
+			return span, true
+		}
+	}
+
+	return Span{X: x, Y: y, Width: 1, Height: 1}, false
+}
+
+// clipSpans returns the given spans clipped to a table of the given size. Spans
+// whose origin is out of the table are dropped, which happens when the table
+// shrinks after its cells were merged.
+//
+// Parameters:
+//   - spans: The spans to clip.
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - []Span: The clipped spans. The given spans are not modified.
+func clipSpans(spans []Span, width, height int) []Span {
+	clipped := make([]Span, 0, len(spans))
+
+	for _, span := range spans {
+		if span.X < 0 || span.X >= width || span.Y < 0 || span.Y >= height {
+			continue
+		}
+
+		span.Width = min(span.Width, width-span.X)
+		span.Height = min(span.Height, height-span.Y)
+
+		clipped = append(clipped, span)
+	}
+
+	return clipped
+}
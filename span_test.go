@@ -0,0 +1,169 @@
+package table_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+// newMerged returns a 3x3 merged table whose center and bottom-right cells are
+// merged.
+func newMerged(t *testing.T) *table.MergedTable {
+	t.Helper()
+
+	src := must(table.NewStringTableFromRows([][]string{{"a", "b", "c"}, {"d", "e", "f"}, {"g", "h", "i"}}))
+	m := must(table.NewMergedTable(src))
+
+	err := m.Merge(table.Span{X: 1, Y: 1, Width: 2, Height: 2})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	return m
+}
+
+func TestMergeRejectsBadSpans(t *testing.T) {
+	tests := []struct {
+		name string
+		span table.Span
+	}{
+		{"empty", table.Span{X: 0, Y: 0, Width: 0, Height: 1}},
+		{"out of bounds", table.Span{X: 2, Y: 0, Width: 2, Height: 1}},
+		{"negative", table.Span{X: -1, Y: 0, Width: 1, Height: 1}},
+		{"overlapping", table.Span{X: 0, Y: 2, Width: 2, Height: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMerged(t)
+
+			if m.Merge(tt.span) == nil {
+				t.Errorf("Merge(%+v) succeeded", tt.span)
+			}
+		})
+	}
+}
+
+func TestMergedTableCells(t *testing.T) {
+	m := newMerged(t)
+
+	if got := m.CellAt(2, 2); got != "e" {
+		t.Errorf("CellAt(2, 2) = %q, want %q", got, "e")
+	}
+
+	if !m.IsCovered(2, 1) || m.IsCovered(1, 1) || m.IsCovered(0, 0) {
+		t.Errorf("IsCovered does not match the span")
+	}
+
+	m.WriteAt(2, 2, "x")
+
+	if got := m.CellAt(1, 1); got != "x" {
+		t.Errorf("redirected write: CellAt(1, 1) = %q, want %q", got, "x")
+	}
+
+	m.SetCoveredWritePolicy(table.RejectCovered)
+	m.WriteAt(2, 2, "y")
+
+	if got := m.CellAt(1, 1); got != "x" {
+		t.Errorf("rejected write: CellAt(1, 1) = %q, want %q", got, "x")
+	}
+
+	if !m.Unmerge(2, 2) || m.Unmerge(2, 2) {
+		t.Errorf("Unmerge does not remove the span exactly once")
+	}
+
+	if got := m.CellAt(2, 2); got != "i" {
+		t.Errorf("after Unmerge: CellAt(2, 2) = %q, want %q", got, "i")
+	}
+}
+
+func TestMergedTableRender(t *testing.T) {
+	tests := []struct {
+		name string
+		opts table.RenderOptions
+		want string
+	}{
+		{
+			name: "ascii",
+			opts: table.RenderOptions{Border: table.BorderASCII, Header: true},
+			want: "+---+---+---+\n" +
+				"| a | b | c |\n" +
+				"+---+---+---+\n" +
+				"| d | e     |\n" +
+				"| g |       |\n" +
+				"+---+-------+\n",
+		},
+		{
+			name: "unicode",
+			opts: table.RenderOptions{Border: table.BorderUnicode, Header: true},
+			want: "┌───┬───┬───┐\n" +
+				"│ a │ b │ c │\n" +
+				"├───┼───┴───┤\n" +
+				"│ d │ e     │\n" +
+				"│ g │       │\n" +
+				"└───┴───────┘\n",
+		},
+		{
+			name: "markdown",
+			opts: table.RenderOptions{Border: table.BorderMarkdown, Header: true},
+			want: "| a   | b   | c   |\n" +
+				"| --- | --- | --- |\n" +
+				"| d   | e   |     |\n" +
+				"| g   |     |     |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			err := newMerged(t).Render(&buf, tt.opts)
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMergedTableShrunk(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		want          string
+	}{
+		{"clipped", 2, 2, "+---+---+\n| a | b |\n| d | e |\n+---+---+\n"},
+		{"dropped", 1, 1, "+---+\n| a |\n+---+\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMerged(t)
+
+			m.Table().ResizeHeight(tt.height)
+			m.Table().ResizeWidth(tt.width)
+
+			var buf bytes.Buffer
+
+			err := m.Render(&buf, table.RenderOptions{})
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			if buf.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+
+			buf.Reset()
+
+			err = m.RenderHTML(&buf, table.HTMLOptions{})
+			if err != nil {
+				t.Fatalf("RenderHTML: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package table
+
+import (
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/PlayerR9/go-commons/errors"
+	"github.com/PlayerR9/table/geom"
+)
+
+// SparseTable is a table that only stores its non-zero cells. It is meant for large
+// tables, such as adjacency matrices, whose cells are almost all zero; see Density.
+//
+// Writing the zero value of T to a cell removes it from the table. A cell is zero
+// only if all of its bits are; hence, a negative zero float is stored and reads
+// back with its sign. Cells are never compared with each other, so T may be an
+// interface, such as error, whose dynamic values are not comparable.
+type SparseTable[T comparable] struct {
+	cells         map[geom.Point]T
+	width, height int
+}
+
+// _ asserts that every instance of SparseTable implements the ReadWriter interface.
+func _[T comparable]() {
+	var _ ReadWriter[T] = (*SparseTable[T])(nil)
+}
+
+// NewSparseTable creates a new sparse table with the given width and height whose
+// cells are all zero.
+//
+// Parameters:
+//   - width: The width of the table.
+//   - height: The height of the table.
+//
+// Returns:
+//   - *SparseTable[T]: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the width or height is less than 0.
+func NewSparseTable[T comparable](width, height int) (*SparseTable[T], error) {
+	if width < 0 {
+		return nil, errors.NewErrInvalidParameter("width", errors.NewErrGTE(0))
+	} else if height < 0 {
+		return nil, errors.NewErrInvalidParameter("height", errors.NewErrGTE(0))
+	}
+
+	return &SparseTable[T]{
+		cells:  make(map[geom.Point]T),
+		width:  width,
+		height: height,
+	}, nil
+}
+
+// NewSparseTableFrom creates a new sparse table with the same size and cells as the
+// given table.
+//
+// Parameters:
+//   - src: The table to copy.
+//
+// Returns:
+//   - *SparseTable[T]: The new table.
+//   - error: An error if the table could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If src is nil.
+func NewSparseTableFrom[T comparable](src Reader[T]) (*SparseTable[T], error) {
+	if src == nil {
+		return nil, errors.NewErrNilParameter("src")
+	}
+
+	s := &SparseTable[T]{
+		cells:  make(map[geom.Point]T),
+		width:  src.Width(),
+		height: src.Height(),
+	}
+
+	for y := 0; y < s.height; y++ {
+		for x := 0; x < s.width; x++ {
+			cell := src.CellAt(x, y)
+			if !isZeroCell(cell) {
+				s.cells[geom.Pt(x, y)] = cell
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// Width returns the width of the table.
+//
+// Returns:
+//   - int: The width of the table. Never negative.
+func (s SparseTable[T]) Width() int {
+	return s.width
+}
+
+// Height returns the height of the table.
+//
+// Returns:
+//   - int: The height of the table. Never negative.
+func (s SparseTable[T]) Height() int {
+	return s.height
+}
+
+// Bounds returns the rectangle covered by the table; that is, the rectangle whose
+// top-left cell is (0, 0) and whose size is the size of the table.
+//
+// Returns:
+//   - geom.Rect: The bounds of the table.
+func (s SparseTable[T]) Bounds() geom.Rect {
+	return geom.Rect{
+		Width:  s.width,
+		Height: s.height,
+	}
+}
+
+// CellAt returns the cell at the given coordinates in the table. However, out-of-bounds
+// coordinates return the zero value of T.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//
+// Returns:
+//   - T: The cell at the given coordinates.
+func (s SparseTable[T]) CellAt(x, y int) T {
+	return s.cells[geom.Pt(x, y)]
+}
+
+// WriteAt writes a cell to the table at the given coordinates. However, out-of-bounds
+// coordinates do nothing.
+//
+// Parameters:
+//   - x: The x-coordinate of the cell.
+//   - y: The y-coordinate of the cell.
+//   - cell: The cell to write to the table.
+//
+// If the receiver is nil, nothing happens.
+func (s *SparseTable[T]) WriteAt(x, y int, cell T) {
+	if s == nil || x < 0 || x >= s.width || y < 0 || y >= s.height {
+		return
+	}
+
+	if s.cells == nil {
+		s.cells = make(map[geom.Point]T)
+	}
+
+	if isZeroCell(cell) {
+		delete(s.cells, geom.Pt(x, y))
+	} else {
+		s.cells[geom.Pt(x, y)] = cell
+	}
+}
+
+// isZeroCell checks whether a cell is the zero value of T. Unlike cell == zero, it
+// tells -0.0 apart from +0.0 and checks interfaces against nil without comparing
+// their dynamic values.
+//
+// Parameters:
+//   - cell: The cell to check.
+//
+// Returns:
+//   - bool: True if the cell is zero, false otherwise.
+func isZeroCell[T comparable](cell T) bool {
+	// The pointer keeps the static type of interface cells.
+	v := reflect.ValueOf(&cell).Elem()
+
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.Float64bits(v.Float()) == 0
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+
+		return math.Float64bits(real(c))|math.Float64bits(imag(c)) == 0
+	default:
+		// IsZero checks interfaces against nil.
+		return v.IsZero()
+	}
+}
+
+// WriteVerticalSequence writes the given values downwards starting at the given
+// coordinates. Values that would be written out of bounds are ignored.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell. (Never changes)
+//   - y: The y-coordinate of the starting cell.
+//   - sequence: The sequence of cells to write to the table.
+//
+// At the end of the function, y points to the cell right below the last cell of the
+// sequence that was written. If the receiver, x or y is nil, nothing happens.
+func (s *SparseTable[T]) WriteVerticalSequence(x, y *int, sequence []T) {
+	if s == nil || x == nil || y == nil || *x < 0 || *x >= s.width {
+		return
+	}
+
+	start, end := max(*y, 0), min(*y+len(sequence), s.height)
+	if start >= end {
+		return
+	}
+
+	for i := start; i < end; i++ {
+		s.WriteAt(*x, i, sequence[i-*y])
+	}
+
+	*y = end
+}
+
+// WriteHorizontalSequence is the equivalent of WriteVerticalSequence but for horizontal
+// sequences.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell. (Never changes)
+//   - sequence: The sequence of cells to write to the table.
+func (s *SparseTable[T]) WriteHorizontalSequence(x, y *int, sequence []T) {
+	if s == nil || x == nil || y == nil || *y < 0 || *y >= s.height {
+		return
+	}
+
+	start, end := max(*x, 0), min(*x+len(sequence), s.width)
+	if start >= end {
+		return
+	}
+
+	for i := start; i < end; i++ {
+		s.WriteAt(i, *y, sequence[i-*x])
+	}
+
+	*x = end
+}
+
+// Cleanup sets all cells in the table to the zero value of T.
+func (s SparseTable[T]) Cleanup() {
+	clear(s.cells)
+}
+
+// ResizeWidth resizes the table to the given width. Cells beyond the new width are
+// removed.
+//
+// Parameters:
+//   - new_width: The new width of the table.
+//
+// Returns:
+//   - error: An error if the table could not be resized.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the new width is less than 0.
+//   - errors.NilReceiver: If the table is nil.
+func (s *SparseTable[T]) ResizeWidth(new_width int) error {
+	if s == nil {
+		return errors.NilReceiver
+	} else if new_width < 0 {
+		return errors.NewErrInvalidParameter("new_width", errors.NewErrGTE(0))
+	}
+
+	if new_width < s.width {
+		for p := range s.cells {
+			if p.X >= new_width {
+				delete(s.cells, p)
+			}
+		}
+	}
+
+	s.width = new_width
+
+	return nil
+}
+
+// ResizeHeight resizes the table to the given height. Cells beyond the new height
+// are removed.
+//
+// Parameters:
+//   - new_height: The new height of the table.
+//
+// Returns:
+//   - error: An error if the table could not be resized.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If the new height is less than 0.
+//   - errors.NilReceiver: If the table is nil.
+func (s *SparseTable[T]) ResizeHeight(new_height int) error {
+	if s == nil {
+		return errors.NilReceiver
+	} else if new_height < 0 {
+		return errors.NewErrInvalidParameter("new_height", errors.NewErrGTE(0))
+	}
+
+	if new_height < s.height {
+		for p := range s.cells {
+			if p.Y >= new_height {
+				delete(s.cells, p)
+			}
+		}
+	}
+
+	s.height = new_height
+
+	return nil
+}
+
+// NonZeroCount returns the number of non-zero cells of the table.
+//
+// Returns:
+//   - int: The number of non-zero cells.
+func (s SparseTable[T]) NonZeroCount() int {
+	return len(s.cells)
+}
+
+// Density returns the fraction of cells of the table that are not zero.
+//
+// Returns:
+//   - float64: The density, in [0, 1]. 0 if the table is empty.
+func (s SparseTable[T]) Density() float64 {
+	if s.width == 0 || s.height == 0 {
+		return 0
+	}
+
+	return float64(len(s.cells)) / (float64(s.width) * float64(s.height))
+}
+
+// sortedPoints returns the coordinates of the non-zero cells in row-major order.
+//
+// Returns:
+//   - []geom.Point: The coordinates.
+func (s SparseTable[T]) sortedPoints() []geom.Point {
+	points := make([]geom.Point, 0, len(s.cells))
+
+	for p := range s.cells {
+		points = append(points, p)
+	}
+
+	slices.SortFunc(points, func(a, b geom.Point) int {
+		if a.Y != b.Y {
+			return a.Y - b.Y
+		}
+
+		return a.X - b.X
+	})
+
+	return points
+}
+
+// NonZero returns an iterator over the non-zero cells of the table and their
+// coordinates, in row-major order. The table must not be modified during the
+// iteration.
+//
+// Returns:
+//   - iter.Seq2[geom.Point, T]: The iterator. Never returns nil.
+func (s SparseTable[T]) NonZero() iter.Seq2[geom.Point, T] {
+	fn := func(yield func(geom.Point, T) bool) {
+		for _, p := range s.sortedPoints() {
+			if !yield(p, s.cells[p]) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Row returns an iterator that scans the table row by row, including the zero cells.
+// The yielded slice is reused between rows.
+//
+// Returns:
+//   - iter.Seq[[]T]: The iterator. Never returns nil.
+func (s SparseTable[T]) Row() iter.Seq[[]T] {
+	fn := func(yield func([]T) bool) {
+		points := s.sortedPoints()
+		row := make([]T, s.width)
+
+		for y := 0; y < s.height; y++ {
+			clear(row)
+
+			for len(points) > 0 && points[0].Y == y {
+				row[points[0].X] = s.cells[points[0]]
+				points = points[1:]
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Cell returns an iterator that scans the table cell by cell, row by row, including
+// the zero cells.
+//
+// Returns:
+//   - iter.Seq[T]: The iterator. Never returns nil.
+func (s SparseTable[T]) Cell() iter.Seq[T] {
+	fn := func(yield func(T) bool) {
+		for row := range s.Row() {
+			for _, cell := range row {
+				if !yield(cell) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// Dense returns a copy of the table as a dense table.
+//
+// Returns:
+//   - *Table[T]: The copy. Never returns nil.
+func (s SparseTable[T]) Dense() *Table[T] {
+	table, _ := NewTable[T](s.width, s.height)
+
+	for p, cell := range s.cells {
+		table.WriteAt(p.X, p.Y, cell)
+	}
+
+	return table
+}
+
+// DenseInto copies every cell of the table, including the zero ones, into the given
+// table; for instance, a Float64Table of the same size. Cells that fall outside of
+// dst are handled by dst.
+//
+// Parameters:
+//   - dst: The table to copy into.
+//
+// Returns:
+//   - error: An error if dst is nil.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If dst is nil.
+func (s SparseTable[T]) DenseInto(dst Writer[T]) error {
+	if dst == nil {
+		return errors.NewErrNilParameter("dst")
+	}
+
+	var y int
+
+	for row := range s.Row() {
+		for x, cell := range row {
+			dst.WriteAt(x, y, cell)
+		}
+
+		y++
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package table_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+func TestSparseTable(t *testing.T) {
+	s := must(table.NewSparseTable[int](3, 2))
+
+	s.WriteAt(2, 1, 5)
+	s.WriteAt(0, 0, 1)
+	s.WriteAt(3, 0, 9)
+	s.WriteAt(-1, 0, 9)
+
+	x, y := 1, 0
+	s.WriteHorizontalSequence(&x, &y, []int{2, 0, 7})
+
+	if x != 3 {
+		t.Errorf("x is %d after the sequence, want 3", x)
+	}
+
+	if got := s.NonZeroCount(); got != 3 {
+		t.Errorf("NonZeroCount is %d, want 3", got)
+	}
+
+	if got, want := s.Density(), 0.5; got != want {
+		t.Errorf("Density is %v, want %v", got, want)
+	}
+
+	want := [][]int{{1, 2, 0}, {0, 0, 5}}
+
+	if got := s.Dense().FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Dense is %v, want %v", got, want)
+	}
+
+	var points []geom.Point
+
+	for p := range s.NonZero() {
+		points = append(points, p)
+	}
+
+	if want := []geom.Point{geom.Pt(0, 0), geom.Pt(1, 0), geom.Pt(2, 1)}; !reflect.DeepEqual(points, want) {
+		t.Errorf("NonZero yields %v, want %v", points, want)
+	}
+
+	// Writing zero removes the cell.
+	s.WriteAt(0, 0, 0)
+
+	if got := s.NonZeroCount(); got != 2 {
+		t.Errorf("NonZeroCount is %d after writing zero, want 2", got)
+	}
+
+	err := s.ResizeWidth(2)
+	if err != nil {
+		t.Fatalf("ResizeWidth: %v", err)
+	}
+
+	if got := s.NonZeroCount(); got != 1 {
+		t.Errorf("NonZeroCount is %d after shrinking, want 1", got)
+	}
+
+	if got := s.CellAt(2, 1); got != 0 {
+		t.Errorf("CellAt(2, 1) is %d after shrinking, want 0", got)
+	}
+}
+
+func TestSparseTableFrom(t *testing.T) {
+	src := must(table.NewTableFromRows([][]int{{0, 1}, {2, 0}}))
+
+	s := must(table.NewSparseTableFrom[int](src))
+
+	if got := s.NonZeroCount(); got != 2 {
+		t.Errorf("NonZeroCount is %d, want 2", got)
+	}
+
+	dst := must(table.NewIntTable(2, 2))
+
+	err := s.DenseInto(dst)
+	if err != nil {
+		t.Fatalf("DenseInto: %v", err)
+	}
+
+	if got, want := dst.FullTable(), src.FullTable(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DenseInto gives %v, want %v", got, want)
+	}
+}
+
+func TestSparseTableNegativeZero(t *testing.T) {
+	s := must(table.NewSparseTable[float64](1, 1))
+
+	s.WriteAt(0, 0, math.Copysign(0, -1))
+
+	if got := s.NonZeroCount(); got != 1 {
+		t.Fatalf("NonZeroCount is %d, want 1", got)
+	}
+
+	if got := s.CellAt(0, 0); !math.Signbit(got) {
+		t.Errorf("CellAt(0, 0) is %v, want -0", got)
+	}
+
+	s.WriteAt(0, 0, 0)
+
+	if got := s.NonZeroCount(); got != 0 {
+		t.Errorf("NonZeroCount is %d after writing +0, want 0", got)
+	}
+
+	// Only nil is the zero value of an interface, even when it holds a zero float.
+	a := must(table.NewSparseTable[any](1, 1))
+
+	a.WriteAt(0, 0, 0.0)
+
+	if got := a.NonZeroCount(); got != 1 {
+		t.Errorf("NonZeroCount is %d after writing any(0.0), want 1", got)
+	}
+}
+
+// listError is an error whose dynamic type is not comparable.
+type listError []string
+
+func (e listError) Error() string {
+	return e[0]
+}
+
+func TestSparseTableIncomparableErrors(t *testing.T) {
+	s := must(table.NewSparseTable[error](2, 1))
+
+	s.WriteAt(0, 0, listError{"first"})
+	s.WriteAt(1, 0, listError{"second"})
+
+	// Overwriting a cell with an error of the same non-comparable type must not panic.
+	s.WriteAt(0, 0, listError{"third"})
+
+	if got := s.NonZeroCount(); got != 2 {
+		t.Fatalf("NonZeroCount is %d, want 2", got)
+	}
+
+	if got := s.CellAt(0, 0); got == nil || got.Error() != "third" {
+		t.Errorf("CellAt(0, 0) is %v, want third", got)
+	}
+
+	s.WriteAt(1, 0, nil)
+
+	if got := s.NonZeroCount(); got != 1 {
+		t.Errorf("NonZeroCount is %d after writing nil, want 1", got)
+	}
+
+	src := must(table.NewErrorTableFromRows([][]error{{listError{"boom"}, nil}}))
+
+	from := must(table.NewSparseTableFrom[error](src))
+
+	if got := from.NonZeroCount(); got != 1 {
+		t.Errorf("NonZeroCount of the copy is %d, want 1", got)
+	}
+
+	if got := from.CellAt(1, 0); got != nil {
+		t.Errorf("CellAt(1, 0) is %v, want nil", got)
+	}
+}
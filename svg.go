@@ -0,0 +1,548 @@
+package table
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// ColorMap maps the values of a heatmap to colors.
+type ColorMap int
+
+const (
+	// Viridis is the perceptually uniform colormap of matplotlib, from dark purple
+	// to yellow.
+	Viridis ColorMap = iota
+
+	// Grayscale goes from black to white.
+	Grayscale
+
+	// Diverging goes from blue to red through white, which is the middle of the
+	// range.
+	Diverging
+)
+
+// String implements the fmt.Stringer interface.
+func (m ColorMap) String() string {
+	switch m {
+	case Viridis:
+		return "viridis"
+	case Grayscale:
+		return "grayscale"
+	case Diverging:
+		return "diverging"
+	default:
+		return fmt.Sprintf("ColorMap(%d)", int(m))
+	}
+}
+
+var (
+	// viridisStops are evenly spaced colors of the Viridis colormap.
+	viridisStops = []color.RGBA{
+		{0x44, 0x01, 0x54, 0xff}, {0x47, 0x2d, 0x7b, 0xff}, {0x3b, 0x52, 0x8b, 0xff},
+		{0x2c, 0x72, 0x8e, 0xff}, {0x21, 0x91, 0x8c, 0xff}, {0x28, 0xae, 0x80, 0xff},
+		{0x5e, 0xc9, 0x62, 0xff}, {0xad, 0xdc, 0x30, 0xff}, {0xfd, 0xe7, 0x25, 0xff},
+	}
+
+	// grayscaleStops are the colors of the Grayscale colormap.
+	grayscaleStops = []color.RGBA{
+		{0x00, 0x00, 0x00, 0xff}, {0xff, 0xff, 0xff, 0xff},
+	}
+
+	// divergingStops are evenly spaced colors of the Diverging colormap.
+	divergingStops = []color.RGBA{
+		{0x21, 0x66, 0xac, 0xff}, {0x67, 0xa9, 0xcf, 0xff}, {0xd1, 0xe5, 0xf0, 0xff},
+		{0xf7, 0xf7, 0xf7, 0xff}, {0xfd, 0xdb, 0xc7, 0xff}, {0xef, 0x8a, 0x62, 0xff},
+		{0xb2, 0x18, 0x2b, 0xff},
+	}
+)
+
+// Color returns the color of the given position within the colormap.
+//
+// Parameters:
+//   - f: The position, from 0 (lowest value) to 1 (highest value). It is clamped.
+//
+// Returns:
+//   - color.RGBA: The color. Opaque.
+func (m ColorMap) Color(f float64) color.RGBA {
+	var stops []color.RGBA
+
+	switch m {
+	case Grayscale:
+		stops = grayscaleStops
+	case Diverging:
+		stops = divergingStops
+	default:
+		stops = viridisStops
+	}
+
+	if math.IsNaN(f) || f <= 0 {
+		return stops[0]
+	} else if f >= 1 {
+		return stops[len(stops)-1]
+	}
+
+	pos := f * float64(len(stops)-1)
+	idx := int(pos)
+	frac := pos - float64(idx)
+
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*frac))
+	}
+
+	a, b := stops[idx], stops[idx+1]
+
+	return color.RGBA{lerp(a.R, b.R), lerp(a.G, b.G), lerp(a.B, b.B), 0xff}
+}
+
+// SVGOptions are the options of the RenderSVG methods. Zero sizes are replaced by
+// defaults that depend on the kind of table.
+type SVGOptions struct {
+	// CellWidth is the width, in pixels, of a cell of a heatmap or of a terminal
+	// column of a text grid.
+	CellWidth float64
+
+	// CellHeight is the height, in pixels, of a cell of a heatmap or of a line of
+	// a text grid.
+	CellHeight float64
+
+	// FontSize is the size, in pixels, of the text of a text grid and of the value
+	// labels and legend of a heatmap.
+	FontSize float64
+
+	// GridLines tells whether lines are drawn between the cells.
+	GridLines bool
+
+	// ColorMap is the colormap of a heatmap.
+	ColorMap ColorMap
+
+	// Normalization maps the cells of a heatmap to the colormap. Cells outside of
+	// its range are clamped.
+	Normalization Normalization
+
+	// Legend tells whether a heatmap has a color bar with the bounds of its range.
+	Legend bool
+
+	// Labels tells whether the value of each cell of a heatmap is printed over it.
+	Labels bool
+
+	// LabelFormat is the fmt format of the value labels. If empty, "%v" is used.
+	LabelFormat string
+}
+
+// svgNum formats a number of an SVG document.
+//
+// Parameters:
+//   - f: The number.
+//
+// Returns:
+//   - string: The formatted number.
+func svgNum(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// svgColor formats a color of an SVG document.
+//
+// Parameters:
+//   - c: The color.
+//
+// Returns:
+//   - string: The color, in the "#rrggbb" form.
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// svgContrast returns the color of the text that is readable over the given
+// background.
+//
+// Parameters:
+//   - c: The background color.
+//
+// Returns:
+//   - string: Either black or white.
+func svgContrast(c color.RGBA) string {
+	if luma(int(c.R), int(c.G), int(c.B)) > 0x80 {
+		return "#000000"
+	}
+
+	return "#ffffff"
+}
+
+// writeSVGGrid writes the lines between the cells of a table.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - xs: The x-coordinates of the column boundaries, from left to right.
+//   - ys: The y-coordinates of the row boundaries, from top to bottom.
+func writeSVGGrid(w *bufio.Writer, xs, ys []float64) {
+	if len(xs) < 2 || len(ys) < 2 {
+		return
+	}
+
+	var d strings.Builder
+
+	for _, x := range xs {
+		fmt.Fprintf(&d, "M%s %sV%s", svgNum(x), svgNum(ys[0]), svgNum(ys[len(ys)-1]))
+	}
+
+	for _, y := range ys {
+		fmt.Fprintf(&d, "M%s %sH%s", svgNum(xs[0]), svgNum(y), svgNum(xs[len(xs)-1]))
+	}
+
+	fmt.Fprintf(w, "  <path d=\"%s\" fill=\"none\" stroke=\"#999999\" stroke-width=\"1\"/>\n", d.String())
+}
+
+// RenderSVG draws the table as an SVG document in which each cell is a character
+// of a monospace grid. Null runes and spaces are not drawn.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - opts: The rendering options. Only the sizes and GridLines are used.
+//
+// Returns:
+//   - error: An error if the document could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (t RuneTable) RenderSVG(w io.Writer, opts SVGOptions) error {
+	cells := make([][]string, 0, t.height)
+	widths := make([]int, t.width)
+
+	for x := range widths {
+		widths[x] = 1
+	}
+
+	for y := 0; y < t.height; y++ {
+		row := make([]string, 0, t.width)
+
+		for x := 0; x < t.width; x++ {
+			var cell string
+
+			if c := t.CellAt(x, y); c != 0 {
+				cell = string(c)
+			}
+
+			row = append(row, cell)
+		}
+
+		cells = append(cells, row)
+	}
+
+	return renderSVGText(w, cells, widths, opts, false)
+}
+
+// RenderSVG draws the table as an SVG document in which the cells are texts laid
+// out on a monospace grid. Each column is as wide as its widest cell plus one
+// terminal column of padding on each side, and newlines within cells start new
+// lines within the same row.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - opts: The rendering options. Only the sizes and GridLines are used.
+//
+// Returns:
+//   - error: An error if the document could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil.
+//   - error: Any error returned by w.
+func (t StringTable) RenderSVG(w io.Writer, opts SVGOptions) error {
+	cells := make([][]string, 0, t.height)
+	widths := make([]int, t.width)
+
+	for y := 0; y < t.height; y++ {
+		row := make([]string, 0, t.width)
+
+		for x := 0; x < t.width; x++ {
+			cell := t.CellAt(x, y)
+			widths[x] = max(widths[x], maxLineWidth(cell))
+
+			row = append(row, cell)
+		}
+
+		cells = append(cells, row)
+	}
+
+	for x := range widths {
+		widths[x] += 2
+	}
+
+	return renderSVGText(w, cells, widths, opts, true)
+}
+
+// renderSVGText draws the given cells as an SVG text grid.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - cells: The cells of the table.
+//   - widths: The width, in terminal columns, of each column.
+//   - opts: The rendering options.
+//   - padded: Whether the cells are left aligned after one column of padding
+//     rather than centered.
+//
+// Returns:
+//   - error: An error if the document could not be written.
+func renderSVGText(w io.Writer, cells [][]string, widths []int, opts SVGOptions, padded bool) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	}
+
+	if opts.FontSize <= 0 {
+		opts.FontSize = 14
+	}
+
+	if opts.CellWidth <= 0 {
+		opts.CellWidth = opts.FontSize * 0.6
+	}
+
+	if opts.CellHeight <= 0 {
+		opts.CellHeight = opts.FontSize * 1.25
+	}
+
+	xs := []float64{0}
+
+	for _, width := range widths {
+		xs = append(xs, xs[len(xs)-1]+float64(width)*opts.CellWidth)
+	}
+
+	ys := []float64{0}
+
+	for _, row := range cells {
+		lines := 1
+
+		for _, cell := range row {
+			lines = max(lines, strings.Count(cell, "\n")+1)
+		}
+
+		ys = append(ys, ys[len(ys)-1]+float64(lines)*opts.CellHeight)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %[1]s %[2]s\">\n", svgNum(xs[len(xs)-1]), svgNum(ys[len(ys)-1]))
+	fmt.Fprintf(bw, "  <g font-family=\"monospace\" font-size=\"%s\" dominant-baseline=\"central\" xml:space=\"preserve\">\n", svgNum(opts.FontSize))
+
+	for y, row := range cells {
+		for x, cell := range row {
+			if strings.TrimSpace(cell) == "" {
+				continue
+			}
+
+			anchor, left := "middle", (xs[x]+xs[x+1])/2
+			if padded {
+				anchor, left = "start", xs[x]+opts.CellWidth
+			}
+
+			for i, line := range strings.Split(cell, "\n") {
+				if line == "" {
+					continue
+				}
+
+				top := ys[y] + (float64(i)+0.5)*opts.CellHeight
+
+				fmt.Fprintf(bw, "    <text x=\"%s\" y=\"%s\" text-anchor=\"%s\">%s</text>\n", svgNum(left), svgNum(top), anchor, html.EscapeString(line))
+			}
+		}
+	}
+
+	bw.WriteString("  </g>\n")
+
+	if opts.GridLines {
+		writeSVGGrid(bw, xs, ys)
+	}
+
+	bw.WriteString("</svg>\n")
+
+	return bw.Flush()
+}
+
+// HeatmapCell is the constraint of the cells of a heatmap.
+type HeatmapCell interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// RenderHeatmap draws a table as an SVG heatmap in which each cell is a rectangle
+// colored after its value. NaN cells are not drawn. It is used by the RenderSVG
+// method of the numeric tables.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - t: The table to draw.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - error: An error if the document could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w or t is nil.
+//   - error: Any error returned by w.
+func RenderHeatmap[T HeatmapCell](w io.Writer, t Reader[T], opts SVGOptions) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	} else if t == nil {
+		return errors.NewErrNilParameter("t")
+	}
+
+	width, height := t.Width(), t.Height()
+
+	if opts.CellWidth <= 0 {
+		opts.CellWidth = 20
+	}
+
+	if opts.CellHeight <= 0 {
+		opts.CellHeight = 20
+	}
+
+	if opts.FontSize <= 0 {
+		opts.FontSize = 10
+	}
+
+	if opts.LabelFormat == "" {
+		opts.LabelFormat = "%v"
+	}
+
+	lo, hi := opts.Normalization.Min, opts.Normalization.Max
+
+	if lo >= hi {
+		lo, hi = math.Inf(1), math.Inf(-1)
+
+		for row := range t.Row() {
+			for _, cell := range row {
+				f := float64(cell)
+
+				if !math.IsNaN(f) && !math.IsInf(f, 0) {
+					lo, hi = min(lo, f), max(hi, f)
+				}
+			}
+		}
+
+		if lo > hi {
+			lo, hi = 0, 1
+		}
+	}
+
+	position := func(f float64) float64 {
+		if hi == lo {
+			return 0.5
+		}
+
+		return (f - lo) / (hi - lo)
+	}
+
+	grid_width := float64(width) * opts.CellWidth
+	grid_height := float64(height) * opts.CellHeight
+
+	total_width, total_height := grid_width, grid_height
+
+	// The legend is a color bar at the right of the grid, with the bounds of the
+	// range at its ends.
+	bar_x := grid_width + opts.CellWidth
+	bar_width := opts.CellWidth
+	bar_height := max(grid_height, 5*opts.FontSize)
+
+	if opts.Legend {
+		label_width := opts.FontSize * 0.6 * float64(max(len(svgNum(lo)), len(svgNum(hi))))
+
+		total_width = bar_x + bar_width + opts.FontSize/2 + label_width
+		total_height = max(total_height, bar_height)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %[1]s %[2]s\">\n", svgNum(total_width), svgNum(total_height))
+
+	if opts.Legend {
+		bw.WriteString("  <defs>\n    <linearGradient id=\"legend\" x1=\"0\" y1=\"1\" x2=\"0\" y2=\"0\">\n")
+
+		for i := 0; i <= 10; i++ {
+			f := float64(i) / 10
+			fmt.Fprintf(bw, "      <stop offset=\"%s\" stop-color=\"%s\"/>\n", svgNum(f), svgColor(opts.ColorMap.Color(f)))
+		}
+
+		bw.WriteString("    </linearGradient>\n  </defs>\n")
+	}
+
+	bw.WriteString("  <g shape-rendering=\"crispEdges\">\n")
+
+	var y int
+
+	for row := range t.Row() {
+		for x, cell := range row {
+			f := float64(cell)
+			if math.IsNaN(f) {
+				continue
+			}
+
+			fmt.Fprintf(bw, "    <rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n",
+				svgNum(float64(x)*opts.CellWidth), svgNum(float64(y)*opts.CellHeight),
+				svgNum(opts.CellWidth), svgNum(opts.CellHeight), svgColor(opts.ColorMap.Color(position(f))))
+		}
+
+		y++
+	}
+
+	bw.WriteString("  </g>\n")
+
+	if opts.Labels {
+		fmt.Fprintf(bw, "  <g font-family=\"sans-serif\" font-size=\"%s\" text-anchor=\"middle\" dominant-baseline=\"central\">\n", svgNum(opts.FontSize))
+
+		y = 0
+
+		for row := range t.Row() {
+			for x, cell := range row {
+				f := float64(cell)
+				if math.IsNaN(f) {
+					continue
+				}
+
+				fmt.Fprintf(bw, "    <text x=\"%s\" y=\"%s\" fill=\"%s\">%s</text>\n",
+					svgNum((float64(x)+0.5)*opts.CellWidth), svgNum((float64(y)+0.5)*opts.CellHeight),
+					svgContrast(opts.ColorMap.Color(position(f))), html.EscapeString(fmt.Sprintf(opts.LabelFormat, cell)))
+			}
+
+			y++
+		}
+
+		bw.WriteString("  </g>\n")
+	}
+
+	if opts.GridLines {
+		xs := make([]float64, 0, width+1)
+		for x := 0; x <= width; x++ {
+			xs = append(xs, float64(x)*opts.CellWidth)
+		}
+
+		ys := make([]float64, 0, height+1)
+		for y := 0; y <= height; y++ {
+			ys = append(ys, float64(y)*opts.CellHeight)
+		}
+
+		writeSVGGrid(bw, xs, ys)
+	}
+
+	if opts.Legend {
+		fmt.Fprintf(bw, "  <rect x=\"%s\" y=\"0\" width=\"%s\" height=\"%s\" fill=\"url(#legend)\" stroke=\"#999999\"/>\n",
+			svgNum(bar_x), svgNum(bar_width), svgNum(bar_height))
+
+		label_x := svgNum(bar_x + bar_width + opts.FontSize/2)
+
+		fmt.Fprintf(bw, "  <g font-family=\"sans-serif\" font-size=\"%s\">\n", svgNum(opts.FontSize))
+		fmt.Fprintf(bw, "    <text x=\"%s\" y=\"0\" dominant-baseline=\"hanging\">%s</text>\n", label_x, svgNum(hi))
+		fmt.Fprintf(bw, "    <text x=\"%s\" y=\"%s\">%s</text>\n", label_x, svgNum(bar_height), svgNum(lo))
+		bw.WriteString("  </g>\n")
+	}
+
+	bw.WriteString("</svg>\n")
+
+	return bw.Flush()
+}
@@ -0,0 +1,52 @@
+package table_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+func TestRenderSVGHeatmap(t *testing.T) {
+	rows := [][]float64{{0, 1, math.NaN()}, {2, 3, 4}}
+	opts := table.SVGOptions{Labels: true}
+
+	var got bytes.Buffer
+
+	err := must(table.NewFloat64TableFromRows(rows)).RenderSVG(&got, opts)
+	if err != nil {
+		t.Fatalf("RenderSVG: %v", err)
+	}
+
+	if n := strings.Count(got.String(), "<rect "); n != 5 {
+		t.Errorf("got %d cells, want 5 since NaN cells are not drawn", n)
+	}
+
+	if n := strings.Count(got.String(), "<text "); n != 5 {
+		t.Errorf("got %d labels, want 5", n)
+	}
+
+	// The generated method and the generic function draw the same heatmap.
+	var want bytes.Buffer
+
+	err = table.RenderHeatmap[float64](&want, must(table.NewTableFromRows(rows)), opts)
+	if err != nil {
+		t.Fatalf("RenderHeatmap: %v", err)
+	}
+
+	if got.String() != want.String() {
+		t.Errorf("RenderSVG and RenderHeatmap differ:\n%s\n%s", got.String(), want.String())
+	}
+}
+
+func TestRenderHeatmapNilParameters(t *testing.T) {
+	if err := table.RenderHeatmap[int](nil, must(table.NewIntTable(1, 1)), table.SVGOptions{}); err == nil {
+		t.Error("nil writer: got nil, want an error")
+	}
+
+	if err := table.RenderHeatmap[int](&bytes.Buffer{}, nil, table.SVGOptions{}); err == nil {
+		t.Error("nil table: got nil, want an error")
+	}
+}
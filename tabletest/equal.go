@@ -0,0 +1,287 @@
+// Package tabletest provides helpers to compare tables in tests, either with each
+// other or with golden files.
+package tabletest
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+// maxListedCells is the maximum number of differing cells listed by AssertEqual.
+const maxListedCells = 10
+
+// AssertEqual checks that two tables have the same size and the same cells, as
+// reflect.DeepEqual compares them. On failure, it reports an error with the two
+// tables side by side and the differing cells marked with a caret.
+//
+// Cells are printed with the %v verb, except the cells of rune tables which are
+// printed as characters.
+//
+// Parameters:
+//   - t: The test.
+//   - got: The actual table.
+//   - want: The expected table.
+//
+// Returns:
+//   - bool: True if the tables are equal, false otherwise.
+func AssertEqual[T any](t testing.TB, got, want table.Reader[T]) bool {
+	t.Helper()
+
+	return AssertEqualFunc(t, got, want, cellFormatter[T](got))
+}
+
+// AssertEqualFunc is like AssertEqual but prints the cells with the given
+// function.
+//
+// Parameters:
+//   - t: The test.
+//   - got: The actual table.
+//   - want: The expected table.
+//   - format: The function that prints a cell. If nil, cells are printed with the
+//     %v verb.
+//
+// Returns:
+//   - bool: True if the tables are equal, false otherwise.
+func AssertEqualFunc[T any](t testing.TB, got, want table.Reader[T], format func(cell T) string) bool {
+	t.Helper()
+
+	diff := Diff(got, want, format)
+	if diff == "" {
+		return true
+	}
+
+	t.Error(diff)
+
+	return false
+}
+
+// Diff describes the differences between two tables.
+//
+// Parameters:
+//   - got: The actual table.
+//   - want: The expected table.
+//   - format: The function that prints a cell. If nil, cells are printed with the
+//     %v verb.
+//
+// Returns:
+//   - string: The description of the differences. Empty if the tables are equal.
+func Diff[T any](got, want table.Reader[T], format func(cell T) string) string {
+	if got == nil || want == nil {
+		if got == nil && want == nil {
+			return ""
+		}
+
+		return fmt.Sprintf("table mismatch: got %v, want %v", describe(got), describe(want))
+	}
+
+	if format == nil {
+		format = func(cell T) string {
+			return fmt.Sprint(cell)
+		}
+	}
+
+	got_width, got_height := got.Width(), got.Height()
+	want_width, want_height := want.Width(), want.Height()
+
+	width, height := max(got_width, want_width), max(got_height, want_height)
+
+	// Tables of different sizes differ even if they have no cells to compare; for
+	// instance, a 0x3 table and a 0x5 one.
+	same_size := got_width == want_width && got_height == want_height
+
+	if !same_size && width*height == 0 {
+		return fmt.Sprintf("table mismatch: got %dx%d, want %dx%d", got_width, got_height, want_width, want_height)
+	}
+
+	in := func(x, y, w, h int) bool {
+		return x < w && y < h
+	}
+
+	// Cells outside of a table are printed as blanks so that the grids stay aligned.
+	cell := func(r table.Reader[T], x, y, w, h int) string {
+		if !in(x, y, w, h) {
+			return ""
+		}
+
+		return format(r.CellAt(x, y))
+	}
+
+	differs := make([][]bool, height)
+	var count int
+	var listed []string
+
+	for y := 0; y < height; y++ {
+		differs[y] = make([]bool, width)
+
+		for x := 0; x < width; x++ {
+			in_got, in_want := in(x, y, got_width, got_height), in(x, y, want_width, want_height)
+
+			if in_got && in_want && reflect.DeepEqual(got.CellAt(x, y), want.CellAt(x, y)) {
+				continue
+			}
+
+			differs[y][x] = true
+			count++
+
+			if len(listed) >= maxListedCells {
+				continue
+			}
+
+			g, w := "<none>", "<none>"
+
+			if in_got {
+				g = strconv.Quote(cell(got, x, y, got_width, got_height))
+			}
+
+			if in_want {
+				w = strconv.Quote(cell(want, x, y, want_width, want_height))
+			}
+
+			listed = append(listed, fmt.Sprintf("  (%d, %d): got %s, want %s", x, y, g, w))
+		}
+	}
+
+	if count == 0 && same_size {
+		return ""
+	}
+
+	widths := make([]int, width)
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			widths[x] = max(widths[x], table.StringWidth(cell(got, x, y, got_width, got_height)), table.StringWidth(cell(want, x, y, want_width, want_height)))
+		}
+	}
+
+	// Each line of a grid is its cells, padded to the width of their column and
+	// separated by a space.
+	line := func(r table.Reader[T], y, w, h int) (string, string) {
+		var text, marks strings.Builder
+
+		for x := 0; x < width; x++ {
+			if x > 0 {
+				text.WriteByte(' ')
+				marks.WriteByte(' ')
+			}
+
+			str := cell(r, x, y, w, h)
+
+			text.WriteString(str)
+			text.WriteString(strings.Repeat(" ", widths[x]-table.StringWidth(str)))
+
+			mark := " "
+			if differs[y][x] {
+				mark = "^"
+			}
+
+			marks.WriteString(mark)
+			marks.WriteString(strings.Repeat(" ", max(widths[x]-1, 0)))
+		}
+
+		return text.String(), marks.String()
+	}
+
+	grid_width := len(widths) - 1
+	for _, w := range widths {
+		grid_width += w
+	}
+
+	grid_width = max(grid_width, len("got"))
+
+	label_width := len(strconv.Itoa(height - 1))
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "table mismatch: %d of %d cells differ", count, width*height)
+
+	if !same_size {
+		fmt.Fprintf(&builder, " (got %dx%d, want %dx%d)", got_width, got_height, want_width, want_height)
+	}
+
+	builder.WriteByte('\n')
+
+	fmt.Fprintf(&builder, "%*s  %-*s   %s\n", label_width, "", grid_width, "got", "want")
+
+	for y := 0; y < height; y++ {
+		g, g_marks := line(got, y, got_width, got_height)
+		w, w_marks := line(want, y, want_width, want_height)
+
+		row := fmt.Sprintf("%*d  %s   %s", label_width, y, padRight(g, grid_width), w)
+		builder.WriteString(strings.TrimRight(row, " "))
+		builder.WriteByte('\n')
+
+		if strings.TrimSpace(g_marks) == "" {
+			continue
+		}
+
+		marks := fmt.Sprintf("%*s  %s   %s", label_width, "", padRight(g_marks, grid_width), w_marks)
+		builder.WriteString(strings.TrimRight(marks, " "))
+		builder.WriteByte('\n')
+	}
+
+	builder.WriteString("differences:\n")
+	builder.WriteString(strings.Join(listed, "\n"))
+
+	if count > len(listed) {
+		fmt.Fprintf(&builder, "\n  ... and %d more", count-len(listed))
+	}
+
+	return builder.String()
+}
+
+// padRight pads a string with spaces up to the given display width.
+//
+// Parameters:
+//   - s: The string to pad.
+//   - width: The display width.
+//
+// Returns:
+//   - string: The padded string.
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-table.StringWidth(s), 0))
+}
+
+// cellFormatter returns the default function that prints the cells of a table.
+//
+// Parameters:
+//   - r: The table.
+//
+// Returns:
+//   - func(cell T) string: The function.
+func cellFormatter[T any](r table.Reader[T]) func(cell T) string {
+	switch any(r).(type) {
+	case table.RuneTable, *table.RuneTable:
+		return func(cell T) string {
+			c := any(cell).(rune)
+			if c == 0 {
+				return " "
+			}
+
+			return string(c)
+		}
+	default:
+		return func(cell T) string {
+			return fmt.Sprint(cell)
+		}
+	}
+}
+
+// describe describes a possibly nil table.
+//
+// Parameters:
+//   - r: The table.
+//
+// Returns:
+//   - string: "<nil>" or the size of the table.
+func describe[T any](r table.Reader[T]) string {
+	if r == nil {
+		return "<nil>"
+	}
+
+	return fmt.Sprintf("a %dx%d table", r.Width(), r.Height())
+}
@@ -0,0 +1,61 @@
+package tabletest_test
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/tabletest"
+)
+
+func TestDiff(t *testing.T) {
+	newTable := func(width, height int) *table.IntTable {
+		tbl, err := table.NewIntTable(width, height)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return tbl
+	}
+
+	changed := newTable(2, 2)
+	changed.WriteAt(1, 1, 7)
+
+	tests := []struct {
+		name      string
+		got, want *table.IntTable
+		equal     bool
+	}{
+		{"equal", newTable(2, 2), newTable(2, 2), true},
+		{"empty", newTable(0, 0), newTable(0, 0), true},
+		{"different cell", changed, newTable(2, 2), false},
+		{"no columns, different heights", newTable(0, 3), newTable(0, 5), false},
+		{"no rows, different widths", newTable(3, 0), newTable(5, 0), false},
+		{"different widths", newTable(2, 2), newTable(3, 2), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := tabletest.Diff[int](tt.got, tt.want, nil)
+
+			if got := diff == ""; got != tt.equal {
+				t.Errorf("Diff is %q, want equal = %t", diff, tt.equal)
+			}
+
+			if !tt.equal && !strings.HasPrefix(diff, "table mismatch") {
+				t.Errorf("Diff is %q, want a table mismatch", diff)
+			}
+		})
+	}
+}
+
+func TestUpdateFlagIsPrefixed(t *testing.T) {
+	if flag.Lookup("tabletest.update") == nil {
+		t.Error("the -tabletest.update flag is not registered")
+	}
+
+	if flag.Lookup("update") != nil {
+		t.Error("the -update flag is registered; it would clash with other packages")
+	}
+}
@@ -0,0 +1,269 @@
+package tabletest
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/PlayerR9/table"
+)
+
+const (
+	// DefaultMaxSize is the maximum width and height of the generated tables when
+	// none is given.
+	DefaultMaxSize = 8
+
+	// DefaultRuns is the number of tables checked by Check when none is given.
+	DefaultRuns = 100
+
+	// maxShrinkSteps bounds the number of successful shrinking steps of Check.
+	maxShrinkSteps = 1000
+)
+
+// Gen generates random tables.
+type Gen[T any] struct {
+	// MaxWidth and MaxHeight are the maximum width and height of the generated
+	// tables. Zero means DefaultMaxSize; negative values are treated as zero.
+	MaxWidth, MaxHeight int
+
+	// Cell returns a random cell. If nil, all the cells are the zero value of T.
+	Cell func(r *rand.Rand) T
+
+	// ShrinkCell returns simpler versions of a cell, the simplest first. If nil,
+	// cells are only shrunk towards the zero value of T.
+	ShrinkCell func(cell T) []T
+}
+
+// size returns the maximum width and height of the generated tables.
+//
+// Returns:
+//   - int: The maximum width.
+//   - int: The maximum height.
+func (g Gen[T]) size() (int, int) {
+	width, height := g.MaxWidth, g.MaxHeight
+
+	if width == 0 {
+		width = DefaultMaxSize
+	}
+
+	if height == 0 {
+		height = DefaultMaxSize
+	}
+
+	return max(width, 0), max(height, 0)
+}
+
+// Generate returns a random table. Its width and height are uniformly distributed
+// in [0, MaxWidth] and [0, MaxHeight]; thus, empty tables are generated too.
+//
+// Parameters:
+//   - r: The source of randomness.
+//
+// Returns:
+//   - *table.Table[T]: The table. Never returns nil.
+func (g Gen[T]) Generate(r *rand.Rand) *table.Table[T] {
+	max_width, max_height := g.size()
+
+	width, height := r.IntN(max_width+1), r.IntN(max_height+1)
+
+	t, _ := table.NewTable[T](width, height)
+
+	if g.Cell == nil {
+		return t
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			t.WriteAt(x, y, g.Cell(r))
+		}
+	}
+
+	return t
+}
+
+// Shrink returns the simpler versions of a table, the simplest first: the table
+// without its bottom or right half, without one of its rows or columns, and with
+// one of its cells shrunk.
+//
+// Parameters:
+//   - t: The table to shrink.
+//
+// Returns:
+//   - iter.Seq[*table.Table[T]]: The simpler tables. Never returns nil.
+func (g Gen[T]) Shrink(t *table.Table[T]) iter.Seq[*table.Table[T]] {
+	fn := func(yield func(*table.Table[T]) bool) {
+		if t == nil {
+			return
+		}
+
+		rows := t.FullTable()
+		width, height := t.Width(), t.Height()
+
+		if height > 1 && !yield(fromRows(rows[:height/2], width)) {
+			return
+		}
+
+		if width > 1 && !yield(mapRows(rows, func(row []T) []T { return row[:width/2] }, width/2)) {
+			return
+		}
+
+		for y := 0; y < height; y++ {
+			without := append(append([][]T{}, rows[:y]...), rows[y+1:]...)
+
+			if !yield(fromRows(without, width)) {
+				return
+			}
+		}
+
+		for x := 0; x < width; x++ {
+			without := mapRows(rows, func(row []T) []T {
+				return append(append([]T{}, row[:x]...), row[x+1:]...)
+			}, width-1)
+
+			if !yield(without) {
+				return
+			}
+		}
+
+		var zero T
+
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				cell := rows[y][x]
+
+				var simpler []T
+
+				if g.ShrinkCell != nil {
+					simpler = g.ShrinkCell(cell)
+				} else if !reflect.DeepEqual(cell, zero) {
+					simpler = []T{zero}
+				}
+
+				for _, c := range simpler {
+					shrunk := fromRows(rows, width)
+					shrunk.WriteAt(x, y, c)
+
+					if !yield(shrunk) {
+						return
+					}
+				}
+			}
+		}
+	}
+
+	return fn
+}
+
+// fromRows returns a table made of copies of the given rows.
+//
+// Parameters:
+//   - rows: The rows of the table.
+//   - width: The width of the table.
+//
+// Returns:
+//   - *table.Table[T]: The table.
+func fromRows[T any](rows [][]T, width int) *table.Table[T] {
+	return mapRows(rows, func(row []T) []T { return row }, width)
+}
+
+// mapRows returns a table made of copies of the given rows, transformed.
+//
+// Parameters:
+//   - rows: The rows of the table.
+//   - fn: The transformation of each row.
+//   - width: The width of the transformed rows.
+//
+// Returns:
+//   - *table.Table[T]: The table.
+func mapRows[T any](rows [][]T, fn func(row []T) []T, width int) *table.Table[T] {
+	t, _ := table.NewTable[T](width, len(rows))
+
+	for y, row := range rows {
+		x := 0
+		t.WriteHorizontalSequence(&x, &y, fn(row))
+	}
+
+	return t
+}
+
+// Check checks a property against random tables. When a table breaks the property,
+// it is shrunk to a minimal one that still breaks it, and the test fails with that
+// table and the seed that reproduces the run.
+//
+// Parameters:
+//   - t: The test.
+//   - g: The generator of the tables.
+//   - runs: The number of tables to check. Zero or less means DefaultRuns.
+//   - seed: The seed of the random tables. Zero means a seed based on the time.
+//   - prop: The property. It returns an error if the table breaks it.
+//
+// Returns:
+//   - bool: True if all the tables satisfy the property, false otherwise.
+func Check[T any](t testing.TB, g Gen[T], runs int, seed uint64, prop func(tbl *table.Table[T]) error) bool {
+	t.Helper()
+
+	if runs <= 0 {
+		runs = DefaultRuns
+	}
+
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+
+	r := rand.New(rand.NewPCG(seed, seed))
+
+	for i := 0; i < runs; i++ {
+		tbl := g.Generate(r)
+
+		err := safeProp(prop, tbl)
+		if err == nil {
+			continue
+		}
+
+		for step := 0; step < maxShrinkSteps; step++ {
+			shrunk := false
+
+			for candidate := range g.Shrink(tbl) {
+				candidate_err := safeProp(prop, candidate)
+				if candidate_err != nil {
+					tbl, err = candidate, candidate_err
+					shrunk = true
+
+					break
+				}
+			}
+
+			if !shrunk {
+				break
+			}
+		}
+
+		t.Errorf("property failed on run %d (seed %d) with a %dx%d table: %v\n%+v", i+1, seed, tbl.Width(), tbl.Height(), err, tbl)
+
+		return false
+	}
+
+	return true
+}
+
+// safeProp calls a property, turning a panic into an error.
+//
+// Parameters:
+//   - prop: The property.
+//   - tbl: The table to check.
+//
+// Returns:
+//   - error: The error returned by the property, or the panic.
+func safeProp[T any](prop func(tbl *table.Table[T]) error, tbl *table.Table[T]) (err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return prop(tbl)
+}
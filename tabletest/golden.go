@@ -0,0 +1,189 @@
+package tabletest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+// update is the flag that tells AssertGolden to rewrite the golden files rather
+// than comparing against them. It is prefixed with the name of the package so that
+// it does not clash with the -update flag of other packages.
+var update = flag.Bool("tabletest.update", false, "rewrite the golden files of tabletest.AssertGolden")
+
+// GoldenDir is the directory of the golden files, relative to the directory of
+// the package under test.
+const GoldenDir = "testdata"
+
+// AssertGolden checks that the text form of a table matches the golden file
+// testdata/<name>.golden. When the tests are run with the -tabletest.update flag,
+// the golden file is written instead, creating the directories as needed.
+//
+// The text form of rune and bool tables is their grid literal (see
+// table.FormatRuneGrid and table.FormatBoolGrid); the one of the other tables is
+// their %v form.
+//
+// Parameters:
+//   - t: The test.
+//   - name: The name of the golden file, without the extension. It may contain
+//     slashes to use sub-directories.
+//   - tbl: The table.
+//
+// Returns:
+//   - bool: True if the table matches the golden file, false otherwise.
+func AssertGolden[T any](t testing.TB, name string, tbl table.Reader[T]) bool {
+	t.Helper()
+
+	if tbl == nil {
+		t.Errorf("golden %q: table is nil", name)
+		return false
+	}
+
+	got := GoldenText(tbl)
+	path := filepath.Join(GoldenDir, filepath.FromSlash(name)+".golden")
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(got), 0o644)
+		}
+
+		if err != nil {
+			t.Errorf("golden %q: %v", name, err)
+			return false
+		}
+
+		return true
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("golden %q: %s does not exist; run the test with -tabletest.update to create it", name, path)
+		return false
+	} else if err != nil {
+		t.Errorf("golden %q: %v", name, err)
+		return false
+	}
+
+	want := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if got == want {
+		return true
+	}
+
+	t.Errorf("golden %q: table does not match %s (run the test with -tabletest.update to rewrite it)\n%s", name, path, lineDiff(got, want))
+
+	return false
+}
+
+// GoldenText returns the text form of a table, as written to the golden files by
+// AssertGolden. It always ends with a newline.
+//
+// Parameters:
+//   - tbl: The table.
+//
+// Returns:
+//   - string: The text form of the table.
+func GoldenText[T any](tbl table.Reader[T]) string {
+	var text string
+
+	switch v := any(tbl).(type) {
+	case *table.RuneTable:
+		text = table.FormatRuneGrid(v)
+	case table.RuneTable:
+		text = table.FormatRuneGrid(&v)
+	case *table.BoolTable:
+		text = table.FormatBoolGrid(v)
+	case table.BoolTable:
+		text = table.FormatBoolGrid(&v)
+	case fmt.Stringer:
+		text = v.String()
+	default:
+		g := gridFormatter[T]{
+			cells: make([][]T, 0, tbl.Height()),
+			width: tbl.Width(),
+		}
+
+		for y := 0; y < tbl.Height(); y++ {
+			row := make([]T, 0, g.width)
+
+			for x := 0; x < g.width; x++ {
+				row = append(row, tbl.CellAt(x, y))
+			}
+
+			g.cells = append(g.cells, row)
+		}
+
+		text = fmt.Sprintf("%v", g)
+	}
+
+	return text + "\n"
+}
+
+// gridFormatter formats the cells of a table that has no String method.
+type gridFormatter[T any] struct {
+	cells [][]T
+	width int
+}
+
+// Format implements the fmt.Formatter interface.
+func (g gridFormatter[T]) Format(s fmt.State, verb rune) {
+	table.FormatTable(s, verb, g.cells, g.width, "")
+}
+
+// lineDiff describes the differences between two texts, line by line.
+//
+// Parameters:
+//   - got: The actual text.
+//   - want: The expected text.
+//
+// Returns:
+//   - string: The lines of both texts; the ones that differ are prefixed with "-"
+//     for the expected text and "+" for the actual one.
+func lineDiff(got, want string) string {
+	got_lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	want_lines := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+
+	var builder strings.Builder
+
+	for i := 0; i < max(len(got_lines), len(want_lines)); i++ {
+		var g, w string
+		has_g, has_w := i < len(got_lines), i < len(want_lines)
+
+		if has_g {
+			g = got_lines[i]
+		}
+
+		if has_w {
+			w = want_lines[i]
+		}
+
+		if has_g && has_w && g == w {
+			builder.WriteString("  ")
+			builder.WriteString(g)
+			builder.WriteByte('\n')
+
+			continue
+		}
+
+		if has_w {
+			builder.WriteString("- ")
+			builder.WriteString(w)
+			builder.WriteByte('\n')
+		}
+
+		if has_g {
+			builder.WriteString("+ ")
+			builder.WriteString(g)
+			builder.WriteByte('\n')
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
@@ -0,0 +1,133 @@
+package widgets
+
+import (
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// List is a vertical list of items in which one item can be selected. The list
+// scrolls so that the selected item is always visible.
+type List struct {
+	items    []string
+	selected int
+	offset   int
+
+	// Marker is the text drawn before the selected item. Other items are
+	// indented by the same amount.
+	Marker string
+}
+
+// NewList creates a new list whose first item, if any, is selected.
+//
+// Parameters:
+//   - items: The items of the list.
+//
+// Returns:
+//   - *List: The new list. Never returns nil.
+func NewList(items ...string) *List {
+	return &List{
+		items:  items,
+		Marker: "> ",
+	}
+}
+
+// Items returns the items of the list.
+//
+// Returns:
+//   - []string: The items of the list.
+func (l List) Items() []string {
+	return l.items
+}
+
+// SetItems replaces the items of the list. The selection is kept in range.
+//
+// Parameters:
+//   - items: The new items.
+func (l *List) SetItems(items ...string) {
+	if l == nil {
+		return
+	}
+
+	l.items = items
+	l.Select(l.selected)
+}
+
+// Selected returns the index of the selected item.
+//
+// Returns:
+//   - int: The index of the selected item. -1 if the list is empty.
+func (l List) Selected() int {
+	if len(l.items) == 0 {
+		return -1
+	}
+
+	return l.selected
+}
+
+// Select selects the item at the given index. Out-of-range indices select the
+// closest item.
+//
+// Parameters:
+//   - idx: The index of the item to select.
+func (l *List) Select(idx int) {
+	if l == nil {
+		return
+	}
+
+	l.selected = max(min(idx, len(l.items)-1), 0)
+}
+
+// Next selects the item after the selected one, if any.
+func (l *List) Next() {
+	if l == nil {
+		return
+	}
+
+	l.Select(l.selected + 1)
+}
+
+// Previous selects the item before the selected one, if any.
+func (l *List) Previous() {
+	if l == nil {
+		return
+	}
+
+	l.Select(l.selected - 1)
+}
+
+// Render implements the Widget interface.
+//
+// Since the scrolling offset is updated so that the selected item is visible, the
+// receiver must not be nil.
+func (l *List) Render(dst *table.RuneTable, rect geom.Rect) {
+	if l == nil || dst == nil || rect.IsEmpty() {
+		return
+	}
+
+	erase(dst, rect)
+
+	if l.selected < l.offset {
+		l.offset = l.selected
+	} else if l.selected >= l.offset+rect.Height {
+		l.offset = l.selected - rect.Height + 1
+	}
+
+	l.offset = max(min(l.offset, len(l.items)-rect.Height), 0)
+
+	marker := []rune(l.Marker)
+	indent := repeat(' ', len(marker))
+
+	for y := 0; y < rect.Height && l.offset+y < len(l.items); y++ {
+		idx := l.offset + y
+
+		var x int
+
+		if idx == l.selected {
+			x = writeLine(dst, rect, 0, y, marker)
+		} else {
+			x = writeLine(dst, rect, 0, y, indent)
+		}
+
+		writeLine(dst, rect, x, y, []rune(l.items[idx]))
+	}
+}
@@ -0,0 +1,82 @@
+package widgets
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// renderRows renders the widget into a table of the given size and returns its
+// rows.
+func renderRows(w Widget, width, height int) []string {
+	dst, err := table.NewRuneTable(width, height)
+	if err != nil {
+		panic(err)
+	}
+
+	w.Render(dst, geom.NewRect(0, 0, width, height))
+
+	rows := make([]string, 0, height)
+
+	for _, row := range dst.FullTable() {
+		rows = append(rows, string(row))
+	}
+
+	return rows
+}
+
+func TestListScrolling(t *testing.T) {
+	l := NewList("a", "b", "c", "d", "e")
+
+	steps := []struct {
+		name string
+		move func()
+		want []string
+	}{
+		{"first", func() {}, []string{"> a", "  b"}},
+		{"second", l.Next, []string{"  a", "> b"}},
+		{"scrolls down", l.Next, []string{"  b", "> c"}},
+		{"last", func() { l.Select(10) }, []string{"  d", "> e"}},
+		{"stays at the bottom", l.Next, []string{"  d", "> e"}},
+		{"previous", l.Previous, []string{"> d", "  e"}},
+		{"scrolls up", l.Previous, []string{"> c", "  d"}},
+		{"first again", func() { l.Select(-3) }, []string{"> a", "  b"}},
+	}
+
+	for _, step := range steps {
+		step.move()
+
+		got := renderRows(l, 3, 2)
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: got %q, want %q", step.name, got, step.want)
+		}
+	}
+}
+
+func TestListShrinks(t *testing.T) {
+	l := NewList("a", "b", "c", "d")
+	l.Select(3)
+
+	_ = renderRows(l, 3, 2)
+
+	// Removing items keeps the selection in range and the offset follows it.
+	l.SetItems("x", "y")
+
+	if got := l.Selected(); got != 1 {
+		t.Errorf("Selected is %d, want 1", got)
+	}
+
+	want := []string{"  x", "> y", "   "}
+
+	if got := renderRows(l, 3, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	l.SetItems()
+
+	if got := l.Selected(); got != -1 {
+		t.Errorf("Selected of an empty list is %d, want -1", got)
+	}
+}
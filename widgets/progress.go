@@ -0,0 +1,177 @@
+package widgets
+
+import (
+	"strconv"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// clampRatio clamps the given ratio to [0, 1].
+//
+// Parameters:
+//   - ratio: The ratio to clamp.
+//
+// Returns:
+//   - float64: The clamped ratio. NaN is treated as 0.
+func clampRatio(ratio float64) float64 {
+	if !(ratio > 0) {
+		return 0
+	} else if ratio > 1 {
+		return 1
+	}
+
+	return ratio
+}
+
+// percentLabel returns the given ratio formatted as a percentage.
+//
+// Parameters:
+//   - ratio: The ratio, in [0, 1].
+//
+// Returns:
+//   - []rune: The label, such as "42%".
+func percentLabel(ratio float64) []rune {
+	return []rune(strconv.Itoa(int(ratio*100)) + "%")
+}
+
+// ProgressBar is a single-row bar that fills from left to right, followed by
+// the percentage of completion.
+type ProgressBar struct {
+	ratio float64
+
+	// Filled is the rune used for the completed part of the bar.
+	Filled rune
+
+	// Empty is the rune used for the remaining part of the bar.
+	Empty rune
+
+	// ShowLabel tells whether the percentage is drawn after the bar.
+	ShowLabel bool
+}
+
+// NewProgressBar creates a new empty progress bar with a label.
+//
+// Returns:
+//   - *ProgressBar: The new progress bar. Never returns nil.
+func NewProgressBar() *ProgressBar {
+	return &ProgressBar{
+		Filled:    '█',
+		Empty:     '░',
+		ShowLabel: true,
+	}
+}
+
+// Ratio returns the completion of the progress bar.
+//
+// Returns:
+//   - float64: The completion, in [0, 1].
+func (p ProgressBar) Ratio() float64 {
+	return p.ratio
+}
+
+// SetRatio sets the completion of the progress bar. Values outside [0, 1] are
+// clamped.
+//
+// Parameters:
+//   - ratio: The completion.
+func (p *ProgressBar) SetRatio(ratio float64) {
+	if p == nil {
+		return
+	}
+
+	p.ratio = clampRatio(ratio)
+}
+
+// Render implements the Widget interface.
+//
+// Only the first row of the region is used.
+func (p *ProgressBar) Render(dst *table.RuneTable, rect geom.Rect) {
+	if p == nil || dst == nil || rect.IsEmpty() {
+		return
+	}
+
+	var label []rune
+
+	if p.ShowLabel {
+		label = append([]rune{' '}, percentLabel(p.ratio)...)
+	}
+
+	width := max(rect.Width-len(label), 0)
+	filled := int(p.ratio * float64(width))
+
+	x := writeLine(dst, rect, 0, 0, repeat(p.Filled, filled))
+	x = writeLine(dst, rect, x, 0, repeat(p.Empty, width-filled))
+	writeLine(dst, rect, x, 0, label)
+}
+
+// Gauge is a progress bar that fills its whole region, with the percentage of
+// completion centered on top of it.
+type Gauge struct {
+	ratio float64
+
+	// Filled is the rune used for the completed part of the gauge.
+	Filled rune
+
+	// Empty is the rune used for the remaining part of the gauge.
+	Empty rune
+
+	// Label is the text drawn at the center of the gauge. If empty, the
+	// percentage of completion is used.
+	Label string
+}
+
+// NewGauge creates a new empty gauge.
+//
+// Returns:
+//   - *Gauge: The new gauge. Never returns nil.
+func NewGauge() *Gauge {
+	return &Gauge{
+		Filled: '█',
+		Empty:  ' ',
+	}
+}
+
+// Ratio returns the completion of the gauge.
+//
+// Returns:
+//   - float64: The completion, in [0, 1].
+func (g Gauge) Ratio() float64 {
+	return g.ratio
+}
+
+// SetRatio sets the completion of the gauge. Values outside [0, 1] are clamped.
+//
+// Parameters:
+//   - ratio: The completion.
+func (g *Gauge) SetRatio(ratio float64) {
+	if g == nil {
+		return
+	}
+
+	g.ratio = clampRatio(ratio)
+}
+
+// Render implements the Widget interface.
+func (g *Gauge) Render(dst *table.RuneTable, rect geom.Rect) {
+	if g == nil || dst == nil || rect.IsEmpty() {
+		return
+	}
+
+	filled := int(g.ratio * float64(rect.Width))
+
+	for y := 0; y < rect.Height; y++ {
+		x := writeLine(dst, rect, 0, y, repeat(g.Filled, filled))
+		writeLine(dst, rect, x, y, repeat(g.Empty, rect.Width-filled))
+	}
+
+	var label []rune
+
+	if g.Label != "" {
+		label = []rune(g.Label)
+	} else {
+		label = percentLabel(g.ratio)
+	}
+
+	writeLine(dst, rect, (rect.Width-len(label))/2, rect.Height/2, label)
+}
@@ -0,0 +1,57 @@
+package widgets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		name  string
+		ratio float64
+		want  string
+	}{
+		{"empty", 0, "░░░░░░ 0%"},
+		{"half", 0.5, "███░░░ 50%"},
+		{"full", 1, "██████ 100%"},
+		{"above one", 3, "██████ 100%"},
+		{"NaN", math.NaN(), "░░░░░░ 0%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProgressBar()
+			p.SetRatio(tt.ratio)
+
+			width := len([]rune(tt.want))
+
+			if got := renderRows(p, width, 1)[0]; got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTabs(t *testing.T) {
+	tabs := NewTabs("a", "b", "c")
+	tabs.Separator = "|"
+
+	steps := []struct {
+		name string
+		move func()
+		want string
+	}{
+		{"first", func() {}, "[a]| b | c "},
+		{"next", tabs.Next, " a |[b]| c "},
+		{"wraps forward", func() { tabs.Next(); tabs.Next() }, "[a]| b | c "},
+		{"wraps backward", tabs.Previous, " a | b |[c]"},
+	}
+
+	for _, step := range steps {
+		step.move()
+
+		if got := renderRows(tabs, 11, 1)[0]; got != step.want {
+			t.Errorf("%s: got %q, want %q", step.name, got, step.want)
+		}
+	}
+}
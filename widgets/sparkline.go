@@ -0,0 +1,113 @@
+package widgets
+
+import (
+	"math"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// sparkBars are the runes used by a sparkline, from the lowest to the highest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline is a single-row chart of a series of non-negative values. When the
+// series is wider than the region, only the most recent values are drawn.
+type Sparkline struct {
+	data []float64
+
+	// Max is the value drawn as a full bar. If it is not positive, the maximum
+	// of the visible values is used.
+	Max float64
+}
+
+// NewSparkline creates a new sparkline.
+//
+// Parameters:
+//   - data: The values of the series.
+//
+// Returns:
+//   - *Sparkline: The new sparkline. Never returns nil.
+func NewSparkline(data ...float64) *Sparkline {
+	return &Sparkline{
+		data: data,
+	}
+}
+
+// Data returns the values of the series.
+//
+// Returns:
+//   - []float64: The values of the series.
+func (s Sparkline) Data() []float64 {
+	return s.data
+}
+
+// Push appends values to the series.
+//
+// Parameters:
+//   - values: The values to append.
+func (s *Sparkline) Push(values ...float64) {
+	if s == nil {
+		return
+	}
+
+	s.data = append(s.data, values...)
+}
+
+// Render implements the Widget interface.
+//
+// Only the first row of the region is used. Negative values and -Inf are drawn as
+// the lowest bar, +Inf as the highest bar and NaN as a blank cell.
+func (s *Sparkline) Render(dst *table.RuneTable, rect geom.Rect) {
+	if s == nil || dst == nil || rect.IsEmpty() {
+		return
+	}
+
+	erase(dst, geom.NewRect(rect.X, rect.Y, rect.Width, 1))
+
+	data := s.data
+	if len(data) > rect.Width {
+		data = data[len(data)-rect.Width:]
+	}
+
+	top := s.Max
+	if top <= 0 || math.IsInf(top, 0) || math.IsNaN(top) {
+		top = 0
+
+		for _, value := range data {
+			if !math.IsInf(value, 0) && !math.IsNaN(value) {
+				top = max(top, value)
+			}
+		}
+	}
+
+	bars := make([]rune, 0, len(data))
+
+	for _, value := range data {
+		bars = append(bars, sparkBar(value, top))
+	}
+
+	writeLine(dst, rect, 0, 0, bars)
+}
+
+// sparkBar returns the bar that represents the given value.
+//
+// Parameters:
+//   - value: The value to represent.
+//   - top: The value drawn as a full bar. Assumed to be finite and non-negative.
+//
+// Returns:
+//   - rune: The bar.
+func sparkBar(value, top float64) rune {
+	switch {
+	case math.IsNaN(value):
+		return ' '
+	case math.IsInf(value, 1):
+		return sparkBars[len(sparkBars)-1]
+	case top <= 0 || value <= 0:
+		return sparkBars[0]
+	}
+
+	idx := int(min(value/top, 1) * float64(len(sparkBars)-1))
+
+	return sparkBars[min(max(idx, 0), len(sparkBars)-1)]
+}
@@ -0,0 +1,43 @@
+package widgets
+
+import (
+	"math"
+	"testing"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+func TestSparklineNonFinite(t *testing.T) {
+	tests := []struct {
+		name string
+		max  float64
+		data []float64
+		want string
+	}{
+		{"+Inf", 0, []float64{1, math.Inf(1), 2}, "▄██"},
+		{"-Inf", 0, []float64{math.Inf(-1), 2}, "▁█"},
+		{"NaN", 0, []float64{math.NaN(), 2}, " █"},
+		{"only non-finite", 0, []float64{math.NaN(), math.Inf(1), math.Inf(-1)}, " █▁"},
+		{"infinite max", math.Inf(1), []float64{1, 2}, "▄█"},
+		{"above max", 1, []float64{0.5, 4}, "▄█"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst, err := table.NewRuneTable(len(tt.data), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := NewSparkline(tt.data...)
+			s.Max = tt.max
+			s.Render(dst, geom.NewRect(0, 0, len(tt.data), 1))
+
+			got := string(dst.FullTable()[0])
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
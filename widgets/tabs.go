@@ -0,0 +1,99 @@
+package widgets
+
+import (
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// Tabs is a single-row header of titles in which one title is selected.
+type Tabs struct {
+	titles   []string
+	selected int
+
+	// Separator is the text drawn between two titles.
+	Separator string
+}
+
+// NewTabs creates a new tab header whose first title, if any, is selected.
+//
+// Parameters:
+//   - titles: The titles of the tabs.
+//
+// Returns:
+//   - *Tabs: The new tab header. Never returns nil.
+func NewTabs(titles ...string) *Tabs {
+	return &Tabs{
+		titles:    titles,
+		Separator: " │ ",
+	}
+}
+
+// Selected returns the index of the selected tab.
+//
+// Returns:
+//   - int: The index of the selected tab. -1 if there are no tabs.
+func (t Tabs) Selected() int {
+	if len(t.titles) == 0 {
+		return -1
+	}
+
+	return t.selected
+}
+
+// Select selects the tab at the given index. Out-of-range indices select the
+// closest tab.
+//
+// Parameters:
+//   - idx: The index of the tab to select.
+func (t *Tabs) Select(idx int) {
+	if t == nil {
+		return
+	}
+
+	t.selected = max(min(idx, len(t.titles)-1), 0)
+}
+
+// Next selects the tab after the selected one, wrapping around at the end.
+func (t *Tabs) Next() {
+	if t == nil || len(t.titles) == 0 {
+		return
+	}
+
+	t.selected = (t.selected + 1) % len(t.titles)
+}
+
+// Previous selects the tab before the selected one, wrapping around at the start.
+func (t *Tabs) Previous() {
+	if t == nil || len(t.titles) == 0 {
+		return
+	}
+
+	t.selected = (t.selected + len(t.titles) - 1) % len(t.titles)
+}
+
+// Render implements the Widget interface.
+//
+// Only the first row of the region is used. The selected title is drawn between
+// square brackets.
+func (t *Tabs) Render(dst *table.RuneTable, rect geom.Rect) {
+	if t == nil || dst == nil || rect.IsEmpty() {
+		return
+	}
+
+	erase(dst, geom.NewRect(rect.X, rect.Y, rect.Width, 1))
+
+	separator := []rune(t.Separator)
+	x := 0
+
+	for i, title := range t.titles {
+		if i > 0 {
+			x = writeLine(dst, rect, x, 0, separator)
+		}
+
+		if i == t.selected {
+			x = writeLine(dst, rect, x, 0, []rune("["+title+"]"))
+		} else {
+			x = writeLine(dst, rect, x, 0, []rune(" "+title+" "))
+		}
+	}
+}
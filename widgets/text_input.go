@@ -0,0 +1,173 @@
+package widgets
+
+import (
+	"slices"
+
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// TextInput is a single-line editable text field. The text scrolls horizontally
+// so that the cursor is always visible.
+type TextInput struct {
+	value  []rune
+	cursor int
+	offset int
+
+	// Placeholder is the text drawn when the field is empty.
+	Placeholder string
+}
+
+// NewTextInput creates a new text field with the cursor at the end of the
+// given text.
+//
+// Parameters:
+//   - value: The initial text.
+//
+// Returns:
+//   - *TextInput: The new text field. Never returns nil.
+func NewTextInput(value string) *TextInput {
+	runes := []rune(value)
+
+	return &TextInput{
+		value:  runes,
+		cursor: len(runes),
+	}
+}
+
+// Value returns the text of the field.
+//
+// Returns:
+//   - string: The text of the field.
+func (t TextInput) Value() string {
+	return string(t.value)
+}
+
+// SetValue replaces the text of the field and moves the cursor to its end.
+//
+// Parameters:
+//   - value: The new text.
+func (t *TextInput) SetValue(value string) {
+	if t == nil {
+		return
+	}
+
+	t.value = []rune(value)
+	t.cursor = len(t.value)
+}
+
+// Cursor returns the position of the cursor, as an index into the runes of
+// the text.
+//
+// Returns:
+//   - int: The position of the cursor.
+func (t TextInput) Cursor() int {
+	return t.cursor
+}
+
+// Insert inserts the given runes at the cursor and moves the cursor after them.
+//
+// Parameters:
+//   - runes: The runes to insert.
+func (t *TextInput) Insert(runes ...rune) {
+	if t == nil {
+		return
+	}
+
+	t.value = slices.Insert(t.value, t.cursor, runes...)
+	t.cursor += len(runes)
+}
+
+// Backspace removes the rune before the cursor, if any.
+func (t *TextInput) Backspace() {
+	if t == nil || t.cursor == 0 {
+		return
+	}
+
+	t.value = slices.Delete(t.value, t.cursor-1, t.cursor)
+	t.cursor--
+}
+
+// Delete removes the rune under the cursor, if any.
+func (t *TextInput) Delete() {
+	if t == nil || t.cursor == len(t.value) {
+		return
+	}
+
+	t.value = slices.Delete(t.value, t.cursor, t.cursor+1)
+}
+
+// Left moves the cursor one rune to the left, if possible.
+func (t *TextInput) Left() {
+	if t == nil || t.cursor == 0 {
+		return
+	}
+
+	t.cursor--
+}
+
+// Right moves the cursor one rune to the right, if possible.
+func (t *TextInput) Right() {
+	if t == nil || t.cursor == len(t.value) {
+		return
+	}
+
+	t.cursor++
+}
+
+// Home moves the cursor to the start of the text.
+func (t *TextInput) Home() {
+	if t == nil {
+		return
+	}
+
+	t.cursor = 0
+}
+
+// End moves the cursor to the end of the text.
+func (t *TextInput) End() {
+	if t == nil {
+		return
+	}
+
+	t.cursor = len(t.value)
+}
+
+// CursorAt returns the coordinates, in the table, at which the cursor was drawn
+// by the last call to Render with the given region. This is where a terminal
+// cursor should be placed.
+//
+// Parameters:
+//   - rect: The region given to Render.
+//
+// Returns:
+//   - int: The x-coordinate of the cursor.
+//   - int: The y-coordinate of the cursor.
+func (t TextInput) CursorAt(rect geom.Rect) (int, int) {
+	return rect.X + t.cursor - t.offset, rect.Y
+}
+
+// Render implements the Widget interface.
+//
+// Only the first row of the region is used. One column is kept free after the
+// text so that the cursor can sit at its end.
+func (t *TextInput) Render(dst *table.RuneTable, rect geom.Rect) {
+	if t == nil || dst == nil || rect.IsEmpty() {
+		return
+	}
+
+	erase(dst, geom.NewRect(rect.X, rect.Y, rect.Width, 1))
+
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	} else if t.cursor >= t.offset+rect.Width {
+		t.offset = t.cursor - rect.Width + 1
+	}
+
+	if len(t.value) == 0 {
+		writeLine(dst, rect, 0, 0, []rune(t.Placeholder))
+		return
+	}
+
+	writeLine(dst, rect, 0, 0, t.value[t.offset:])
+}
@@ -0,0 +1,60 @@
+package widgets
+
+import (
+	"testing"
+
+	"github.com/PlayerR9/table/geom"
+)
+
+func TestTextInputScrolling(t *testing.T) {
+	in := NewTextInput("abcdefgh")
+
+	steps := []struct {
+		name    string
+		move    func()
+		want    string
+		cursorX int
+	}{
+		{"end", func() {}, "efgh ", 4},
+		{"left", in.Left, "efgh ", 3},
+		{"home", in.Home, "abcde", 0},
+		{"right stays", in.Right, "abcde", 1},
+		{"past the right edge", func() {
+			for range 5 {
+				in.Right()
+			}
+		}, "cdefg", 4},
+		{"insert", func() { in.Insert('X', 'Y') }, "efXYg", 4},
+		{"backspace", in.Backspace, "efXgh", 3},
+		{"delete", in.Delete, "efXh ", 3},
+		{"end again", in.End, "efXh ", 4},
+	}
+
+	for _, step := range steps {
+		step.move()
+
+		got := renderRows(in, 5, 1)[0]
+		if got != step.want {
+			t.Errorf("%s: got %q, want %q", step.name, got, step.want)
+		}
+
+		if x, y := in.CursorAt(geom.NewRect(0, 0, 5, 1)); x != step.cursorX || y != 0 {
+			t.Errorf("%s: cursor at (%d, %d), want (%d, 0)", step.name, x, y, step.cursorX)
+		}
+	}
+}
+
+func TestTextInputPlaceholder(t *testing.T) {
+	in := NewTextInput("")
+	in.Placeholder = "name"
+
+	if got := renderRows(in, 6, 1)[0]; got != "name  " {
+		t.Errorf("got %q, want %q", got, "name  ")
+	}
+
+	in.Insert('a')
+
+	if got := renderRows(in, 6, 1)[0]; got != "a     " {
+		t.Errorf("got %q, want %q", got, "a     ")
+	}
+}
@@ -0,0 +1,89 @@
+package widgets
+
+import (
+	"github.com/PlayerR9/table"
+	"github.com/PlayerR9/table/geom"
+)
+
+// Widget is a component that can be drawn into a region of a rune table.
+type Widget interface {
+	// Render draws the widget into the given region of the table. Cells outside
+	// of the region are never written.
+	//
+	// Parameters:
+	//   - dst: The table to draw into.
+	//   - rect: The region of the table the widget owns.
+	//
+	// If dst is nil, nothing happens.
+	Render(dst *table.RuneTable, rect geom.Rect)
+}
+
+// erase fills the given region of the table with spaces.
+//
+// Parameters:
+//   - dst: The table to draw into.
+//   - rect: The region to clear.
+func erase(dst *table.RuneTable, rect geom.Rect) {
+	for y := 0; y < rect.Height; y++ {
+		for x := 0; x < rect.Width; x++ {
+			dst.WriteAt(rect.X+x, rect.Y+y, ' ')
+		}
+	}
+}
+
+// writeLine writes the given text on a row of the region, starting at the given
+// column. Whatever does not fit in the region is ignored.
+//
+// Parameters:
+//   - dst: The table to draw into.
+//   - rect: The region to draw into.
+//   - x: The column, relative to the region, of the first rune.
+//   - y: The row, relative to the region.
+//   - text: The text to write.
+//
+// Returns:
+//   - int: The column, relative to the region, right after the last rune written.
+func writeLine(dst *table.RuneTable, rect geom.Rect, x, y int, text []rune) int {
+	if y < 0 || y >= rect.Height || x >= rect.Width {
+		return x
+	}
+
+	if x < 0 {
+		if -x >= len(text) {
+			return x + len(text)
+		}
+
+		text = text[-x:]
+		x = 0
+	}
+
+	if x+len(text) > rect.Width {
+		text = text[:rect.Width-x]
+	}
+
+	actualX, actualY := rect.X+x, rect.Y+y
+	dst.WriteHorizontalSequence(&actualX, &actualY, text)
+
+	return x + len(text)
+}
+
+// repeat returns a slice made of n times the given rune.
+//
+// Parameters:
+//   - r: The rune to repeat.
+//   - n: The number of repetitions. Negative values are treated as 0.
+//
+// Returns:
+//   - []rune: The repeated runes.
+func repeat(r rune, n int) []rune {
+	if n <= 0 {
+		return nil
+	}
+
+	runes := make([]rune, n)
+	for i := range runes {
+		runes[i] = r
+	}
+
+	return runes
+}
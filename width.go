@@ -0,0 +1,130 @@
+package table
+
+import (
+	"unicode"
+)
+
+// wideRanges are the ranges of runes that take two columns on a terminal; that is,
+// the East Asian Wide and Fullwidth characters as well as most emojis.
+var wideRanges = [][2]rune{
+	{0x1100, 0x115F},
+	{0x2E80, 0x303E},
+	{0x3041, 0x33FF},
+	{0x3400, 0x4DBF},
+	{0x4E00, 0x9FFF},
+	{0xA000, 0xA4CF},
+	{0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF},
+	{0xFE30, 0xFE4F},
+	{0xFF00, 0xFF60},
+	{0xFFE0, 0xFFE6},
+	{0x1F300, 0x1F64F},
+	{0x1F900, 0x1F9FF},
+	{0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
+
+// RuneWidth returns the number of columns the given rune takes when printed on
+// a terminal.
+//
+// Parameters:
+//   - r: The rune to measure.
+//
+// Returns:
+//   - int: 0 for control and combining runes, 2 for wide runes and 1 otherwise.
+func RuneWidth(r rune) int {
+	if r == 0x200B || unicode.IsControl(r) || unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return 0
+	}
+
+	for _, rng := range wideRanges {
+		if r < rng[0] {
+			break
+		} else if r <= rng[1] {
+			return 2
+		}
+	}
+
+	return 1
+}
+
+// StringWidth returns the number of columns the given string takes when printed
+// on a terminal.
+//
+// Parameters:
+//   - s: The string to measure.
+//
+// Returns:
+//   - int: The sum of the widths of the runes of the string.
+func StringWidth(s string) int {
+	var width int
+
+	for _, r := range s {
+		width += RuneWidth(r)
+	}
+
+	return width
+}
+
+// truncateWidth returns the longest prefix of the given string that fits in the
+// given number of columns.
+//
+// Parameters:
+//   - s: The string to truncate.
+//   - width: The number of columns.
+//
+// Returns:
+//   - string: The prefix of s.
+//   - string: The rest of s.
+func truncateWidth(s string, width int) (string, string) {
+	var total int
+
+	for i, r := range s {
+		total += RuneWidth(r)
+		if total > width {
+			return s[:i], s[i:]
+		}
+	}
+
+	return s, ""
+}
+
+// padWidth pads the given string with spaces so that it takes the given number
+// of columns.
+//
+// Parameters:
+//   - s: The string to pad.
+//   - width: The number of columns.
+//   - align: Where the string is placed within the columns.
+//
+// Returns:
+//   - string: The padded string. s itself if it is already wide enough.
+func padWidth(s string, width int, align Alignment) string {
+	gap := width - StringWidth(s)
+	if gap <= 0 {
+		return s
+	}
+
+	var left int
+
+	switch align {
+	case AlignRight:
+		left = gap
+	case AlignCenter:
+		left = gap / 2
+	}
+
+	buff := make([]byte, 0, len(s)+gap)
+
+	for i := 0; i < left; i++ {
+		buff = append(buff, ' ')
+	}
+
+	buff = append(buff, s...)
+
+	for i := left; i < gap; i++ {
+		buff = append(buff, ' ')
+	}
+
+	return string(buff)
+}
@@ -0,0 +1,611 @@
+package table
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+const (
+	// xlsxMainNS is the namespace of the SpreadsheetML parts.
+	xlsxMainNS = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+
+	// xlsxRelNS is the namespace of the relationship identifiers.
+	xlsxRelNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+
+	// xlsxPkgRelNS is the namespace of the relationship parts.
+	xlsxPkgRelNS = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+	// xlsxMaxRows and xlsxMaxColumns are the maximum size of a worksheet.
+	xlsxMaxRows, xlsxMaxColumns = 1048576, 16384
+
+	// xlsxMaxCells is the maximum number of cells of a table read from a worksheet.
+	// It protects ReadXLSX against sparse worksheets whose last cell is far away,
+	// since the table spans from A1 to that cell.
+	xlsxMaxCells = 1 << 24
+)
+
+// xlsxKind is the kind of value of a worksheet cell.
+type xlsxKind int
+
+const (
+	// xlsxEmpty is a cell that is not written.
+	xlsxEmpty xlsxKind = iota
+
+	// xlsxString is a text cell.
+	xlsxString
+
+	// xlsxNumber is a numeric cell.
+	xlsxNumber
+
+	// xlsxBool is a boolean cell.
+	xlsxBool
+)
+
+// Sheet is a worksheet of a workbook written by WriteXLSX.
+type Sheet struct {
+	// Name is the name of the worksheet. If empty, "Sheet<n>" is used.
+	Name string
+
+	// FreezeHeader tells whether the first row stays visible when scrolling.
+	FreezeHeader bool
+
+	// ColumnWidths are the widths, in characters, of the first columns. Columns
+	// with a width of 0, or without a width, have the default width.
+	ColumnWidths []float64
+
+	width, height int
+
+	// cell returns the kind and the textual value of a cell.
+	cell func(x, y int) (xlsxKind, string)
+}
+
+// NewSheet creates a new worksheet from a table. Integer and floating-point cells
+// become numeric cells, boolean cells become boolean cells, and the other cells
+// become text cells; empty strings and nil values are left empty. Since rune is
+// int32, the cells of a RuneTable are the exception: they become text cells and
+// the zero rune is left empty.
+//
+// Parameters:
+//   - name: The name of the worksheet.
+//   - t: The cells of the worksheet.
+//
+// Returns:
+//   - Sheet: The new worksheet.
+//   - error: An error if the worksheet could not be created.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If t is nil or too large for a worksheet.
+func NewSheet[T any](name string, t Reader[T]) (Sheet, error) {
+	if t == nil {
+		return Sheet{}, errors.NewErrNilParameter("t")
+	}
+
+	width, height := t.Width(), t.Height()
+
+	if width > xlsxMaxColumns {
+		return Sheet{}, errors.NewErrInvalidParameter("t", fmt.Errorf("%d columns exceed the limit of %d", width, xlsxMaxColumns))
+	} else if height > xlsxMaxRows {
+		return Sheet{}, errors.NewErrInvalidParameter("t", fmt.Errorf("%d rows exceed the limit of %d", height, xlsxMaxRows))
+	}
+
+	cell := func(x, y int) (xlsxKind, string) {
+		return xlsxValue(t.CellAt(x, y))
+	}
+
+	switch any(t).(type) {
+	case RuneTable, *RuneTable:
+		cell = func(x, y int) (xlsxKind, string) {
+			r := any(t.CellAt(x, y)).(rune)
+			if r == 0 {
+				return xlsxEmpty, ""
+			}
+
+			return xlsxString, string(r)
+		}
+	}
+
+	return Sheet{
+		Name:   name,
+		width:  width,
+		height: height,
+		cell:   cell,
+	}, nil
+}
+
+// xlsxValue returns the kind and the textual value of a worksheet cell.
+//
+// Parameters:
+//   - cell: The cell.
+//
+// Returns:
+//   - xlsxKind: The kind of the cell.
+//   - string: The textual value of the cell.
+func xlsxValue(cell any) (xlsxKind, string) {
+	switch cell := cell.(type) {
+	case nil:
+		return xlsxEmpty, ""
+	case string:
+		if cell == "" {
+			return xlsxEmpty, ""
+		}
+
+		return xlsxString, cell
+	case bool:
+		if cell {
+			return xlsxBool, "1"
+		}
+
+		return xlsxBool, "0"
+	case int:
+		return xlsxNumber, strconv.FormatInt(int64(cell), 10)
+	case int8:
+		return xlsxNumber, strconv.FormatInt(int64(cell), 10)
+	case int16:
+		return xlsxNumber, strconv.FormatInt(int64(cell), 10)
+	case int32:
+		return xlsxNumber, strconv.FormatInt(int64(cell), 10)
+	case int64:
+		return xlsxNumber, strconv.FormatInt(cell, 10)
+	case uint:
+		return xlsxNumber, strconv.FormatUint(uint64(cell), 10)
+	case uint8:
+		return xlsxNumber, strconv.FormatUint(uint64(cell), 10)
+	case uint16:
+		return xlsxNumber, strconv.FormatUint(uint64(cell), 10)
+	case uint32:
+		return xlsxNumber, strconv.FormatUint(uint64(cell), 10)
+	case uint64:
+		return xlsxNumber, strconv.FormatUint(cell, 10)
+	case uintptr:
+		return xlsxNumber, strconv.FormatUint(uint64(cell), 10)
+	case float32:
+		return xlsxFloat(float64(cell), 32)
+	case float64:
+		return xlsxFloat(cell, 64)
+	case error:
+		return xlsxString, cell.Error()
+	default:
+		return xlsxString, fmt.Sprint(cell)
+	}
+}
+
+// xlsxFloat returns the kind and the textual value of a floating-point cell.
+// Since worksheets cannot hold NaN and infinities, those become text cells.
+//
+// Parameters:
+//   - f: The value of the cell.
+//   - bit_size: The precision of the value.
+//
+// Returns:
+//   - xlsxKind: The kind of the cell.
+//   - string: The textual value of the cell.
+func xlsxFloat(f float64, bit_size int) (xlsxKind, string) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return xlsxString, strconv.FormatFloat(f, 'g', -1, bit_size)
+	}
+
+	return xlsxNumber, strconv.FormatFloat(f, 'g', -1, bit_size)
+}
+
+// xlsxColumnName returns the name of a column, such as "A", "Z" or "AA".
+//
+// Parameters:
+//   - x: The 0-based index of the column.
+//
+// Returns:
+//   - string: The name of the column.
+func xlsxColumnName(x int) string {
+	var name []byte
+
+	for x++; x > 0; x = (x - 1) / 26 {
+		name = append([]byte{byte('A' + (x-1)%26)}, name...)
+	}
+
+	return string(name)
+}
+
+// checkSheetName checks that a worksheet name is accepted by spreadsheet
+// applications.
+//
+// Parameters:
+//   - name: The name to check.
+//
+// Returns:
+//   - error: An error if the name is not valid.
+func checkSheetName(name string) error {
+	if len([]rune(name)) > 31 {
+		return fmt.Errorf("name %q is longer than 31 characters", name)
+	} else if strings.ContainsAny(name, "[]:*?/\\") {
+		return fmt.Errorf("name %q contains one of []:*?/\\", name)
+	} else if strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return fmt.Errorf("name %q starts or ends with an apostrophe", name)
+	}
+
+	return nil
+}
+
+// WriteXLSX writes a minimal Office Open XML workbook (.xlsx) made of the given
+// worksheets.
+//
+// Parameters:
+//   - w: The writer to write the workbook to.
+//   - sheets: The worksheets of the workbook, in order.
+//
+// Returns:
+//   - error: An error if the workbook could not be written.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If w is nil, there are no worksheets, or a
+//     worksheet has an invalid or duplicate name.
+//   - error: Any error returned by w.
+func WriteXLSX(w io.Writer, sheets ...Sheet) error {
+	if w == nil {
+		return errors.NewErrNilParameter("w")
+	} else if len(sheets) == 0 {
+		return errors.NewErrInvalidParameter("sheets", errors.NewErrEmpty(sheets))
+	}
+
+	names := make([]string, 0, len(sheets))
+	seen := make(map[string]bool, len(sheets))
+
+	for i, sheet := range sheets {
+		name := sheet.Name
+		if name == "" {
+			name = "Sheet" + strconv.Itoa(i+1)
+		}
+
+		err := checkSheetName(name)
+		if err != nil {
+			return errors.NewErrInvalidParameter(fmt.Sprintf("sheets[%d]", i), err)
+		}
+
+		key := strings.ToLower(name)
+		if seen[key] {
+			return errors.NewErrInvalidParameter(fmt.Sprintf("sheets[%d]", i), fmt.Errorf("name %q is used more than once", name))
+		}
+
+		seen[key] = true
+		names = append(names, name)
+	}
+
+	zw := zip.NewWriter(w)
+
+	parts := []struct {
+		name string
+		data any
+	}{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", xlsxRelationships{
+			NS: xlsxPkgRelNS,
+			Rels: []xlsxRelationship{{
+				ID:     "rId1",
+				Type:   "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument",
+				Target: "xl/workbook.xml",
+			}},
+		}},
+		{"xl/workbook.xml", xlsxWorkbookOf(names)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+	}
+
+	for _, part := range parts {
+		err := writeXMLPart(zw, part.name, part.data)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, sheet := range sheets {
+		err := writeXMLPart(zw, "xl/worksheets/sheet"+strconv.Itoa(i+1)+".xml", sheet.worksheet())
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeXMLPart writes a part of the package.
+//
+// Parameters:
+//   - zw: The package.
+//   - name: The name of the part.
+//   - data: The XML element of the part.
+//
+// Returns:
+//   - error: An error if the part could not be written.
+func writeXMLPart(zw *zip.Writer, name string, data any) error {
+	pw, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(pw, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	return xml.NewEncoder(pw).Encode(data)
+}
+
+// xlsxTypes is the [Content_Types].xml part.
+type xlsxTypes struct {
+	XMLName   xml.Name       `xml:"Types"`
+	NS        string         `xml:"xmlns,attr"`
+	Defaults  []xlsxDefault  `xml:"Default"`
+	Overrides []xlsxOverride `xml:"Override"`
+}
+
+// xlsxDefault is the content type of the parts with a given extension.
+type xlsxDefault struct {
+	Extension   string `xml:"Extension,attr"`
+	ContentType string `xml:"ContentType,attr"`
+}
+
+// xlsxOverride is the content type of a given part.
+type xlsxOverride struct {
+	PartName    string `xml:"PartName,attr"`
+	ContentType string `xml:"ContentType,attr"`
+}
+
+// xlsxContentTypes returns the content types of a workbook.
+//
+// Parameters:
+//   - count: The number of worksheets.
+//
+// Returns:
+//   - xlsxTypes: The content types.
+func xlsxContentTypes(count int) xlsxTypes {
+	types := xlsxTypes{
+		NS: "http://schemas.openxmlformats.org/package/2006/content-types",
+		Defaults: []xlsxDefault{
+			{"rels", "application/vnd.openxmlformats-package.relationships+xml"},
+			{"xml", "application/xml"},
+		},
+		Overrides: []xlsxOverride{
+			{"/xl/workbook.xml", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"},
+		},
+	}
+
+	for i := 1; i <= count; i++ {
+		types.Overrides = append(types.Overrides, xlsxOverride{
+			PartName:    "/xl/worksheets/sheet" + strconv.Itoa(i) + ".xml",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml",
+		})
+	}
+
+	return types
+}
+
+// xlsxRelationships is a relationship part.
+type xlsxRelationships struct {
+	XMLName xml.Name           `xml:"Relationships"`
+	NS      string             `xml:"xmlns,attr"`
+	Rels    []xlsxRelationship `xml:"Relationship"`
+}
+
+// xlsxRelationship is a relationship between two parts.
+type xlsxRelationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+// xlsxWorkbookRels returns the relationships of a workbook.
+//
+// Parameters:
+//   - count: The number of worksheets.
+//
+// Returns:
+//   - xlsxRelationships: The relationships.
+func xlsxWorkbookRels(count int) xlsxRelationships {
+	rels := xlsxRelationships{
+		NS: xlsxPkgRelNS,
+	}
+
+	for i := 1; i <= count; i++ {
+		rels.Rels = append(rels.Rels, xlsxRelationship{
+			ID:     "rId" + strconv.Itoa(i),
+			Type:   "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet",
+			Target: "worksheets/sheet" + strconv.Itoa(i) + ".xml",
+		})
+	}
+
+	return rels
+}
+
+// xlsxWorkbook is the xl/workbook.xml part.
+type xlsxWorkbook struct {
+	XMLName xml.Name         `xml:"workbook"`
+	NS      string           `xml:"xmlns,attr"`
+	RelNS   string           `xml:"xmlns:r,attr"`
+	Sheets  []xlsxSheetEntry `xml:"sheets>sheet"`
+}
+
+// xlsxSheetEntry is the declaration of a worksheet in the workbook.
+type xlsxSheetEntry struct {
+	Name    string `xml:"name,attr"`
+	SheetID int    `xml:"sheetId,attr"`
+	RelID   string `xml:"r:id,attr"`
+}
+
+// xlsxWorkbookOf returns the workbook part.
+//
+// Parameters:
+//   - names: The names of the worksheets.
+//
+// Returns:
+//   - xlsxWorkbook: The workbook part.
+func xlsxWorkbookOf(names []string) xlsxWorkbook {
+	wb := xlsxWorkbook{
+		NS:    xlsxMainNS,
+		RelNS: xlsxRelNS,
+	}
+
+	for i, name := range names {
+		wb.Sheets = append(wb.Sheets, xlsxSheetEntry{
+			Name:    name,
+			SheetID: i + 1,
+			RelID:   "rId" + strconv.Itoa(i+1),
+		})
+	}
+
+	return wb
+}
+
+// xlsxWorksheet is a xl/worksheets/sheet<n>.xml part.
+type xlsxWorksheet struct {
+	XMLName xml.Name        `xml:"worksheet"`
+	NS      string          `xml:"xmlns,attr"`
+	Views   *xlsxSheetViews `xml:"sheetViews,omitempty"`
+	Cols    *xlsxCols       `xml:"cols,omitempty"`
+	Data    xlsxSheetData   `xml:"sheetData"`
+}
+
+// xlsxSheetData are the rows of a worksheet. It is always written, even when
+// the worksheet is empty.
+type xlsxSheetData struct {
+	Rows []xlsxRow `xml:"row"`
+}
+
+// xlsxSheetViews are the views of a worksheet.
+type xlsxSheetViews struct {
+	View xlsxSheetView `xml:"sheetView"`
+}
+
+// xlsxSheetView is a view of a worksheet.
+type xlsxSheetView struct {
+	WorkbookViewID int       `xml:"workbookViewId,attr"`
+	Pane           *xlsxPane `xml:"pane,omitempty"`
+}
+
+// xlsxPane is a frozen pane of a view.
+type xlsxPane struct {
+	YSplit      int    `xml:"ySplit,attr"`
+	TopLeftCell string `xml:"topLeftCell,attr"`
+	ActivePane  string `xml:"activePane,attr"`
+	State       string `xml:"state,attr"`
+}
+
+// xlsxCols are the column definitions of a worksheet.
+type xlsxCols struct {
+	Cols []xlsxCol `xml:"col"`
+}
+
+// xlsxCol is the definition of a range of columns.
+type xlsxCol struct {
+	Min         int     `xml:"min,attr"`
+	Max         int     `xml:"max,attr"`
+	Width       float64 `xml:"width,attr"`
+	CustomWidth int     `xml:"customWidth,attr"`
+}
+
+// xlsxRow is a row of a worksheet.
+type xlsxRow struct {
+	R     int        `xml:"r,attr"`
+	Cells []xlsxCell `xml:"c"`
+}
+
+// xlsxCell is a cell of a worksheet.
+type xlsxCell struct {
+	R      string      `xml:"r,attr"`
+	T      string      `xml:"t,attr,omitempty"`
+	V      string      `xml:"v,omitempty"`
+	Inline *xlsxInline `xml:"is,omitempty"`
+}
+
+// xlsxInline is the inline text of a cell.
+type xlsxInline struct {
+	T xlsxText `xml:"t"`
+}
+
+// xlsxText is a run of text.
+type xlsxText struct {
+	Space string `xml:"xml:space,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// worksheet returns the worksheet part of the sheet.
+//
+// Returns:
+//   - xlsxWorksheet: The worksheet part.
+func (s Sheet) worksheet() xlsxWorksheet {
+	ws := xlsxWorksheet{
+		NS: xlsxMainNS,
+	}
+
+	if s.FreezeHeader {
+		ws.Views = &xlsxSheetViews{
+			View: xlsxSheetView{
+				Pane: &xlsxPane{
+					YSplit:      1,
+					TopLeftCell: "A2",
+					ActivePane:  "bottomLeft",
+					State:       "frozen",
+				},
+			},
+		}
+	}
+
+	for x, width := range s.ColumnWidths {
+		if width <= 0 {
+			continue
+		}
+
+		if ws.Cols == nil {
+			ws.Cols = &xlsxCols{}
+		}
+
+		ws.Cols.Cols = append(ws.Cols.Cols, xlsxCol{
+			Min:         x + 1,
+			Max:         x + 1,
+			Width:       width,
+			CustomWidth: 1,
+		})
+	}
+
+	for y := 0; y < s.height; y++ {
+		row := xlsxRow{
+			R: y + 1,
+		}
+
+		for x := 0; x < s.width; x++ {
+			kind, value := s.cell(x, y)
+
+			cell := xlsxCell{
+				R: xlsxColumnName(x) + strconv.Itoa(y+1),
+			}
+
+			switch kind {
+			case xlsxEmpty:
+				continue
+			case xlsxString:
+				cell.T = "inlineStr"
+				cell.Inline = &xlsxInline{T: xlsxText{Text: value}}
+
+				if strings.TrimSpace(value) != value {
+					cell.Inline.T.Space = "preserve"
+				}
+			case xlsxNumber:
+				cell.V = value
+			case xlsxBool:
+				cell.T = "b"
+				cell.V = value
+			}
+
+			row.Cells = append(row.Cells, cell)
+		}
+
+		if len(row.Cells) > 0 {
+			ws.Data.Rows = append(ws.Data.Rows, row)
+		}
+	}
+
+	return ws
+}
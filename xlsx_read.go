@@ -0,0 +1,348 @@
+package table
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/PlayerR9/go-commons/errors"
+)
+
+// xlsxReadRels is a relationship part, as read from a package.
+type xlsxReadRels struct {
+	Rels []xlsxRelationship `xml:"Relationship"`
+}
+
+// target returns the part targeted by the relationship with the given identifier
+// or, if id is empty, by the first relationship whose type ends with kind.
+//
+// Parameters:
+//   - base: The name of the part the relationships belong to.
+//   - id: The identifier of the relationship.
+//   - kind: The last segment of the type of the relationship.
+//
+// Returns:
+//   - string: The name of the targeted part.
+//   - bool: True if the relationship was found, false otherwise.
+func (r xlsxReadRels) target(base, id, kind string) (string, bool) {
+	for _, rel := range r.Rels {
+		if id != "" && rel.ID != id {
+			continue
+		} else if id == "" && !strings.HasSuffix(rel.Type, "/"+kind) {
+			continue
+		}
+
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), true
+		}
+
+		return path.Join(path.Dir(base), rel.Target), true
+	}
+
+	return "", false
+}
+
+// xlsxReadWorkbook is the workbook part, as read from a package.
+type xlsxReadWorkbook struct {
+	Sheets []struct {
+		Name  string `xml:"name,attr"`
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxStringItem is a possibly rich string of a shared strings part or of an
+// inline string cell.
+type xlsxStringItem struct {
+	T    *string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+// String implements the fmt.Stringer interface.
+func (si xlsxStringItem) String() string {
+	if si.T != nil {
+		return *si.T
+	}
+
+	var builder strings.Builder
+
+	for _, run := range si.Runs {
+		builder.WriteString(run.T)
+	}
+
+	return builder.String()
+}
+
+// xlsxReadSharedStrings is the shared strings part, as read from a package.
+type xlsxReadSharedStrings struct {
+	Items []xlsxStringItem `xml:"si"`
+}
+
+// xlsxReadCell is a cell of a worksheet part, as read from a package. Worksheets
+// are read cell by cell, so that the size limit is enforced before the whole
+// worksheet is in memory.
+type xlsxReadCell struct {
+	R      string          `xml:"r,attr"`
+	T      string          `xml:"t,attr"`
+	V      string          `xml:"v"`
+	Inline *xlsxStringItem `xml:"is"`
+}
+
+// ReadXLSX reads the first worksheet of an Office Open XML workbook (.xlsx) into
+// a string table. Numbers are kept as written in the workbook, booleans become
+// "TRUE" or "FALSE" and missing cells become empty strings. The table spans from
+// the A1 cell to the last row and column holding a cell, and is limited to 2^24
+// cells.
+//
+// Parameters:
+//   - r: The reader to read the workbook from.
+//   - size: The size of the workbook, in bytes.
+//
+// Returns:
+//   - *StringTable: The cells of the first worksheet.
+//   - error: An error if the workbook could not be read.
+//
+// Errors:
+//   - *errors.ErrInvalidParameter: If r is nil.
+//   - error: If the workbook is malformed, if the table would have more than 2^24
+//     cells or any error returned by r.
+func ReadXLSX(r io.ReaderAt, size int64) (*StringTable, error) {
+	if r == nil {
+		return nil, errors.NewErrNilParameter("r")
+	}
+
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	var root xlsxReadRels
+
+	err = readXMLPart(zr, "_rels/.rels", &root)
+	if err != nil {
+		return nil, err
+	}
+
+	wb_name, ok := root.target("", "", "officeDocument")
+	if !ok {
+		return nil, fmt.Errorf("xlsx: package has no workbook")
+	}
+
+	var wb xlsxReadWorkbook
+
+	err = readXMLPart(zr, wb_name, &wb)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wb.Sheets) == 0 {
+		return nil, fmt.Errorf("xlsx: workbook has no worksheet")
+	}
+
+	var wb_rels xlsxReadRels
+
+	err = readXMLPart(zr, path.Join(path.Dir(wb_name), "_rels", path.Base(wb_name)+".rels"), &wb_rels)
+	if err != nil {
+		return nil, err
+	}
+
+	ws_name, ok := wb_rels.target(wb_name, wb.Sheets[0].RelID, "")
+	if !ok {
+		return nil, fmt.Errorf("xlsx: worksheet %q has no part", wb.Sheets[0].Name)
+	}
+
+	var shared xlsxReadSharedStrings
+
+	ss_name, ok := wb_rels.target(wb_name, "", "sharedStrings")
+	if ok {
+		err = readXMLPart(zr, ss_name, &shared)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zr.Open(ws_name)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: %w", err)
+	}
+	defer f.Close()
+
+	type entry struct {
+		x, y  int
+		value string
+	}
+
+	var entries []entry
+	var width, height int
+
+	x, y := -1, -1
+
+	dec := xml.NewDecoder(f)
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("xlsx: part %q: %w", ws_name, err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "row":
+			y++
+			x = -1
+
+			for _, attr := range start.Attr {
+				if attr.Name.Local != "r" {
+					continue
+				}
+
+				r, err := strconv.Atoi(attr.Value)
+				if err == nil && r > 0 {
+					y = r - 1
+				}
+			}
+
+			continue
+		case "c":
+		default:
+			continue
+		}
+
+		var c xlsxReadCell
+
+		err = dec.DecodeElement(&c, &start)
+		if err != nil {
+			return nil, fmt.Errorf("xlsx: part %q: %w", ws_name, err)
+		}
+
+		if c.R != "" {
+			cx, cy, err := parseCellRef(c.R)
+			if err != nil {
+				return nil, err
+			}
+
+			x, y = cx, cy
+		} else {
+			x++
+		}
+
+		if x >= xlsxMaxColumns || y >= xlsxMaxRows {
+			return nil, fmt.Errorf("xlsx: cell %s%d is out of the worksheet", xlsxColumnName(x), y+1)
+		}
+
+		var value string
+
+		switch c.T {
+		case "s":
+			idx, err := strconv.Atoi(c.V)
+			if err != nil || idx < 0 || idx >= len(shared.Items) {
+				return nil, fmt.Errorf("xlsx: cell %s%d refers to an invalid shared string %q", xlsxColumnName(x), y+1, c.V)
+			}
+
+			value = shared.Items[idx].String()
+		case "inlineStr":
+			if c.Inline != nil {
+				value = c.Inline.String()
+			}
+		case "b":
+			if c.V == "1" {
+				value = "TRUE"
+			} else {
+				value = "FALSE"
+			}
+		default:
+			value = c.V
+		}
+
+		if value == "" {
+			continue
+		}
+
+		width = max(width, x+1)
+		height = max(height, y+1)
+
+		// Cells written more than once count as many times, which bounds entries.
+		if int64(width)*int64(height) > xlsxMaxCells || len(entries) >= xlsxMaxCells {
+			return nil, fmt.Errorf("xlsx: worksheet of %dx%d cells exceeds the limit of %d cells", width, height, xlsxMaxCells)
+		}
+
+		entries = append(entries, entry{x: x, y: y, value: value})
+	}
+
+	table, err := NewStringTable(width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		table.WriteAt(e.x, e.y, e.value)
+	}
+
+	return table, nil
+}
+
+// readXMLPart decodes a part of the package.
+//
+// Parameters:
+//   - zr: The package.
+//   - name: The name of the part.
+//   - v: The value to decode the part into.
+//
+// Returns:
+//   - error: An error if the part is missing or could not be decoded.
+func readXMLPart(zr *zip.Reader, name string, v any) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("xlsx: %w", err)
+	}
+	defer f.Close()
+
+	err = xml.NewDecoder(f).Decode(v)
+	if err != nil {
+		return fmt.Errorf("xlsx: part %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// parseCellRef parses a cell reference such as "B3" or "$B$3".
+//
+// Parameters:
+//   - ref: The reference to parse.
+//
+// Returns:
+//   - int: The 0-based x-coordinate of the cell.
+//   - int: The 0-based y-coordinate of the cell.
+//   - error: An error if the reference is malformed.
+func parseCellRef(ref string) (int, int, error) {
+	s := strings.ReplaceAll(strings.ToUpper(ref), "$", "")
+
+	var x, i int
+
+	for i < len(s) && s[i] >= 'A' && s[i] <= 'Z' {
+		x = x*26 + int(s[i]-'A'+1)
+		i++
+
+		if x > xlsxMaxColumns {
+			return 0, 0, fmt.Errorf("xlsx: invalid cell reference %q", ref)
+		}
+	}
+
+	y, err := strconv.Atoi(s[i:])
+	if i == 0 || err != nil || y < 1 || y > xlsxMaxRows {
+		return 0, 0, fmt.Errorf("xlsx: invalid cell reference %q", ref)
+	}
+
+	return x - 1, y - 1, nil
+}
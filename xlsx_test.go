@@ -0,0 +1,132 @@
+package table_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PlayerR9/table"
+)
+
+// writeWorkbook writes a workbook with a single worksheet holding src and applies
+// edit to the XML of the worksheet.
+func writeWorkbook(t *testing.T, src table.Reader[string], edit func(string) string) []byte {
+	t.Helper()
+
+	sheet, err := table.NewSheet("Data", src)
+	if err != nil {
+		t.Fatalf("NewSheet: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = table.WriteXLSX(&buf, sheet)
+	if err != nil {
+		t.Fatalf("WriteXLSX: %v", err)
+	}
+
+	if edit == nil {
+		return buf.Bytes()
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+
+	zw := zip.NewWriter(&out)
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := io.ReadAll(rc)
+		rc.Close()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			data = []byte(edit(string(data)))
+		}
+
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w.Write(data)
+	}
+
+	err = zw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return out.Bytes()
+}
+
+func TestXLSXRoundTrip(t *testing.T) {
+	src := must(table.NewStringTableFromRows([][]string{{"a", "b"}, {"", "1.5"}}))
+
+	data := writeWorkbook(t, src, nil)
+
+	got, err := table.ReadXLSX(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadXLSX: %v", err)
+	}
+
+	if !reflect.DeepEqual(got.FullTable(), src.FullTable()) {
+		t.Errorf("got %q, want %q", got.FullTable(), src.FullTable())
+	}
+}
+
+func TestReadXLSXRejectsHugeSheets(t *testing.T) {
+	src := must(table.NewStringTableFromRows([][]string{{"a"}}))
+
+	data := writeWorkbook(t, src, func(s string) string {
+		far := `<row r="1048576"><c r="XFD1048576" t="inlineStr"><is><t>x</t></is></c></row></sheetData>`
+
+		return strings.Replace(s, "</sheetData>", far, 1)
+	})
+
+	_, err := table.ReadXLSX(bytes.NewReader(data), int64(len(data)))
+	if err == nil || !strings.Contains(err.Error(), "exceeds the limit") {
+		t.Errorf("got %v, want a size limit error", err)
+	}
+}
+
+func TestXLSXRuneCells(t *testing.T) {
+	src := must(table.NewRuneTableFromRows([][]rune{{'a', 0}, {'界', '1'}}))
+
+	sheet, err := table.NewSheet("Runes", src)
+	if err != nil {
+		t.Fatalf("NewSheet: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	err = table.WriteXLSX(&buf, sheet)
+	if err != nil {
+		t.Fatalf("WriteXLSX: %v", err)
+	}
+
+	got, err := table.ReadXLSX(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("ReadXLSX: %v", err)
+	}
+
+	want := [][]string{{"a", ""}, {"界", "1"}}
+
+	if !reflect.DeepEqual(got.FullTable(), want) {
+		t.Errorf("got %q, want %q", got.FullTable(), want)
+	}
+}